@@ -0,0 +1,55 @@
+package typemeta
+
+import (
+	"errors"
+)
+
+// Access is the access mode of a struct field, i.e. whether its value is accepted when decoding and emitted when encoding
+type Access int
+
+const (
+	// ReadWrite fields are both accepted when decoding and emitted when encoding
+	ReadWrite Access = iota
+	// ReadOnly fields, e.g. `ID` and `CreatedAt`, are emitted when encoding but not accepted when decoding (see `Converter.ReadOnly`)
+	ReadOnly
+	// WriteOnly fields, e.g. `Password`, are accepted when decoding but never emitted when encoding (see `MarshalValue`)
+	WriteOnly
+)
+
+// String returns the access mode as in the `access` tag, e.g. "readonly"
+func (a Access) String() string {
+	switch a {
+	case ReadOnly:
+		return "readonly"
+	case WriteOnly:
+		return "writeonly"
+	default:
+		return "readwrite"
+	}
+}
+
+// parseAccess parses the value of an `access` tag
+func parseAccess(str string) (Access, error) {
+	switch str {
+	case "readwrite":
+		return ReadWrite, nil
+	case "readonly":
+		return ReadOnly, nil
+	case "writeonly":
+		return WriteOnly, nil
+	default:
+		return ReadWrite, errors.New("unknown access mode \"" + str + "\", expected \"readonly\", \"writeonly\", or \"readwrite\"")
+	}
+}
+
+// ReadOnlyPolicy is the policy of a converter for values of read-only fields when converting maps to structs
+type ReadOnlyPolicy int
+
+const (
+	// IgnoreReadOnly ignores values of read-only fields
+	IgnoreReadOnly ReadOnlyPolicy = iota
+	// RejectReadOnly fails the conversion if a value is received for a read-only field
+	RejectReadOnly
+	// AllowReadOnly sets values of read-only fields like other fields, e.g. for trusted data
+	AllowReadOnly
+)
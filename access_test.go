@@ -0,0 +1,62 @@
+package typemeta
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type accessAccount struct {
+	ID        int       `json:"id" access:"readonly"`
+	CreatedAt time.Time `json:"createdAt" access:"readonly"`
+	Email     string    `json:"email"`
+	Password  string    `json:"password" access:"writeonly"`
+}
+
+func TestAccess(t *testing.T) {
+	s := NewSchema()
+	account := s.GetStruct(accessAccount{})
+
+	t.Run("tags", func(t *testing.T) {
+		if account.EnsureFieldByName("id").Access != ReadOnly || account.EnsureFieldByName("password").Access != WriteOnly || account.EnsureFieldByName("email").Access != ReadWrite {
+			t.Error("expected access modes of tags")
+		}
+		type invalidAccess struct {
+			ID int `access:"hidden"`
+		}
+		var fieldErr *FieldError
+		if err := s.Register(invalidAccess{}); !errors.As(err, &fieldErr) || fieldErr.Field != "ID" {
+			t.Error("expected invalid access tag to be reported")
+		}
+	})
+
+	data := []byte(`{"id": 5, "email": "ada@example.com", "password": "secret"}`)
+
+	t.Run("decode", func(t *testing.T) {
+		v, err := s.UnmarshalValue(accessAccount{}, data)
+		if err != nil {
+			t.Fatal("failed unmarshaling value: " + err.Error())
+		}
+		if v.(accessAccount) != (accessAccount{Email: "ada@example.com", Password: "secret"}) {
+			t.Error("expected read-only field to be ignored")
+		}
+		if _, err := (&Converter{Schema: s, ReadOnly: RejectReadOnly}).UnmarshalValue(accessAccount{}, data); err == nil {
+			t.Error("expected read-only field to be rejected")
+		}
+		v, err = (&Converter{Schema: s, ReadOnly: AllowReadOnly}).UnmarshalValue(accessAccount{}, data)
+		if err != nil || v.(accessAccount).ID != 5 {
+			t.Error("expected read-only field to be allowed")
+		}
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		data, err := s.MarshalValue(accessAccount{ID: 5, Email: "ada@example.com", Password: "secret"})
+		if err != nil {
+			t.Fatal("failed marshaling value: " + err.Error())
+		}
+		expected := `{"id":5,"createdAt":"0001-01-01T00:00:00Z","email":"ada@example.com"}`
+		if string(data) != expected {
+			t.Error("expected " + expected + " but received " + string(data))
+		}
+	})
+}
@@ -0,0 +1,190 @@
+package typemeta
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// AliasPolicy is the policy of a converter for maps with both the name and an alias of a field as keys when converting maps to structs
+type AliasPolicy int
+
+const (
+	// PreferCanonical uses the value of the name of the field, or of the first alias of the field (see `StructField.Aliases`) if only aliases are keys
+	PreferCanonical AliasPolicy = iota
+	// RejectAliasConflict fails the conversion
+	RejectAliasConflict
+	// LastAliasWins uses the value of the key which comes last in the JSON object when unmarshaling (see `Converter.UnmarshalValue`).
+	// Since maps have no key order, `PreferCanonical` is used when converting maps which were not unmarshaled by the converter.
+	LastAliasWins
+)
+
+// AliasUsage is the usage of an alias of a struct field by a converted value
+type AliasUsage struct {
+	Path  string       // Path of the field within the converted value (see `Path.String`)
+	Field *StructField // Struct field of the alias
+	Alias string       // Alias which was used
+}
+
+// hasAlias returns whether the field has the specified alias, optionally matched case-insensitively
+func (sf StructField) hasAlias(name string, fold bool) bool {
+	return sf.aliasRank(name, fold) > 0
+}
+
+// aliasRank returns 0 for names of the field other than its aliases, and the position of an alias in the aliases of the field starting from 1 otherwise
+func (sf StructField) aliasRank(name string, fold bool) int {
+	for i, alias := range sf.Aliases {
+		if alias == name || (fold && strings.EqualFold(alias, name)) {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// structKey is a key of a map converted to a struct and the field of the struct it is converted to
+type structKey struct {
+	key   reflect.Value
+	field *StructField
+	alias string // Alias of the field used by the key, if any
+}
+
+// structKeys resolves the keys of a map converted to a struct to fields of the struct. When multiple keys resolve to the same field and
+// any of them is an alias, the key to use is chosen according to the alias policy.
+func (c *conversion) structKeys(value reflect.Value, strct *Struct) ([]structKey, error) {
+	var keys []structKey
+	fieldKeys := map[string]int{}
+	tag := strct.nameTag()
+	mapIter := value.MapRange()
+	for mapIter.Next() {
+		key := mapIter.Key()
+		name := key.String()
+		structField := strct.FieldByName(name)
+		if structField == nil && c.CaseInsensitive {
+			structField = strct.FieldByNameFold(name)
+		}
+		if structField == nil {
+			return nil, errors.New("unrecognized key \"" + fmt.Sprint(key.Interface()) + "\" does not exist in struct \"" + strct.String() + "\"")
+		}
+		sk := structKey{key: key, field: structField}
+		if name != structField.Name && name != structField.NameFor(tag) && structField.hasAlias(name, c.CaseInsensitive) {
+			sk.alias = name
+		}
+		fieldKey := fmt.Sprint(structField.indexPath())
+		i, ok := fieldKeys[fieldKey]
+		if !ok {
+			fieldKeys[fieldKey] = len(keys)
+			keys = append(keys, sk)
+			continue
+		}
+		if sk.alias == "" && keys[i].alias == "" {
+			keys = append(keys, sk)
+			continue
+		}
+		if c.AliasPolicy == RejectAliasConflict {
+			return nil, errors.New("keys \"" + keys[i].key.String() + "\" and \"" + name + "\" both set field \"" + structField.String() + "\"")
+		}
+		if c.preferKey(value, sk, keys[i]) {
+			keys[i] = sk
+		}
+	}
+	return keys, nil
+}
+
+// preferKey returns whether a key is preferred over another key of the same field according to the alias policy
+func (c *conversion) preferKey(value reflect.Value, key structKey, otherKey structKey) bool {
+	if positions, ok := c.keyPositions[value.Pointer()]; ok && c.AliasPolicy == LastAliasWins {
+		return positions[key.key.String()] > positions[otherKey.key.String()]
+	}
+	return key.field.aliasRank(key.alias, c.CaseInsensitive) < otherKey.field.aliasRank(otherKey.alias, c.CaseInsensitive)
+}
+
+// reportAlias calls the alias callback with the path of the value nested at the specified step
+func (c *conversion) reportAlias(step *PathStep, usage AliasUsage) {
+	if c.OnAlias == nil {
+		return
+	}
+	usage.Path = c.pathTo(step)
+	c.OnAlias(usage)
+}
+
+// decode unmarshals JSON into a pointer to a slice or map of interfaces like `json.Unmarshal`, recording the key positions of the
+// decoded objects if they are needed by the alias policy
+func (c *conversion) decode(data []byte, v interface{}) error {
+	if c.AliasPolicy != LastAliasWins {
+		return json.Unmarshal(data, v)
+	}
+	decoded, err := c.decodeOrdered(data)
+	if err != nil || decoded == nil {
+		return err
+	}
+	rv := reflect.ValueOf(v).Elem()
+	decodedValue := reflect.ValueOf(decoded)
+	if !decodedValue.Type().AssignableTo(rv.Type()) {
+		return errors.New("cannot unmarshal " + decodedValue.Type().String() + " into " + rv.Type().String())
+	}
+	rv.Set(decodedValue)
+	return nil
+}
+
+// decodeOrdered decodes JSON like `json.Unmarshal` into an `interface{}`, recording the key positions of the decoded objects
+func (c *conversion) decodeOrdered(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	v, err := c.decodeOrderedValue(decoder)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("invalid data after top-level value")
+	}
+	return v, nil
+}
+
+func (c *conversion) decodeOrderedValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch token {
+	case json.Delim('{'):
+		object := map[string]interface{}{}
+		positions := map[string]int{}
+		for position := 0; decoder.More(); position++ {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyToken.(string)
+			if object[key], err = c.decodeOrderedValue(decoder); err != nil {
+				return nil, err
+			}
+			positions[key] = position
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		if c.keyPositions == nil {
+			c.keyPositions = map[uintptr]map[string]int{}
+		}
+		c.keyPositions[reflect.ValueOf(object).Pointer()] = positions
+		return object, nil
+	case json.Delim('['):
+		array := []interface{}{}
+		for decoder.More() {
+			elem, err := c.decodeOrderedValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, elem)
+		}
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+		return array, nil
+	default:
+		return token, nil
+	}
+}
@@ -0,0 +1,73 @@
+package typemeta
+
+import (
+	"testing"
+)
+
+type aliasProfile struct {
+	DisplayName string `json:"displayName" alias:"display_name,name"`
+	Email       string `json:"email"`
+}
+
+type aliasAccount struct {
+	Profile aliasProfile `json:"profile"`
+}
+
+func TestAliases(t *testing.T) {
+	s := NewSchema()
+	profile := s.GetStruct(aliasProfile{})
+
+	t.Run("lookup", func(t *testing.T) {
+		field := profile.FieldByName("display_name")
+		if field == nil || field.Name != "DisplayName" || len(field.Aliases) != 2 || field.Aliases[1] != "name" {
+			t.Error("expected field to be found by alias")
+		}
+		if profile.FieldByName("NAME") != nil || profile.FieldByNameFold("NAME") == nil {
+			t.Error("expected aliases to be matched case-sensitively unless folded")
+		}
+		profile.EditField("email").Alias("mail")
+		if field := profile.FieldByName("mail"); field == nil || field.Name != "Email" {
+			t.Error("expected alias added using the editor to be found")
+		}
+	})
+
+	t.Run("usage", func(t *testing.T) {
+		usages := []AliasUsage{}
+		c := &Converter{Schema: s, OnAlias: func(usage AliasUsage) {
+			usages = append(usages, usage)
+		}}
+		v, err := c.UnmarshalValue(aliasAccount{}, []byte(`{"profile": {"display_name": "Ada"}}`))
+		if err != nil || v.(aliasAccount).Profile.DisplayName != "Ada" {
+			t.Fatal("expected alias to be decoded")
+		}
+		if len(usages) != 1 || usages[0].Alias != "display_name" || usages[0].Path != "profile.displayName" || usages[0].Field.Name != "DisplayName" {
+			t.Error("expected alias usage to be reported")
+		}
+	})
+
+	t.Run("policy", func(t *testing.T) {
+		data := []byte(`{"name": "Old", "displayName": "Canonical", "display_name": "Alias"}`)
+		v, err := (&Converter{Schema: s}).UnmarshalValue(aliasProfile{}, data)
+		if err != nil || v.(aliasProfile).DisplayName != "Canonical" {
+			t.Error("expected canonical key to be preferred")
+		}
+		v, err = (&Converter{Schema: s}).ConvertInterfaceValue(map[string]interface{}{"name": "Old", "display_name": "Alias"}, aliasProfile{})
+		if err != nil || v.(aliasProfile).DisplayName != "Alias" {
+			t.Error("expected first alias to be preferred over later aliases")
+		}
+		if _, err := (&Converter{Schema: s, AliasPolicy: RejectAliasConflict}).UnmarshalValue(aliasProfile{}, data); err == nil {
+			t.Error("expected conflicting keys to be rejected")
+		}
+		if _, err := (&Converter{Schema: s, AliasPolicy: RejectAliasConflict}).UnmarshalValue(aliasProfile{}, []byte(`{"name": "Old"}`)); err != nil {
+			t.Error("expected single alias to be accepted: " + err.Error())
+		}
+		v, err = (&Converter{Schema: s, AliasPolicy: LastAliasWins}).UnmarshalValue(aliasProfile{}, data)
+		if err != nil || v.(aliasProfile).DisplayName != "Alias" {
+			t.Error("expected last key to win")
+		}
+		v, err = (&Converter{Schema: s, AliasPolicy: LastAliasWins}).UnmarshalValue([]aliasProfile{}, []byte(`[{"displayName": "Canonical", "name": "Old"}]`))
+		if err != nil || v.([]aliasProfile)[0].DisplayName != "Old" {
+			t.Error("expected last key of nested object to win")
+		}
+	})
+}
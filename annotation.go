@@ -0,0 +1,103 @@
+package typemeta
+
+import (
+	"sync"
+)
+
+// Key is a typed key of an annotation. Each key created with `NewKey` is distinct, even if created with the same name.
+type Key[T any] struct {
+	name string
+}
+
+// NewKey returns a new annotation key for values of the type parameter
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name}
+}
+
+// Name returns the name of the key
+func (k *Key[T]) Name() string {
+	return k.name
+}
+
+func (k *Key[T]) String() string {
+	return "Key(" + k.name + ")"
+}
+
+// Annotatable is implemented by all type metas of this package and by struct fields, which can be annotated using `Annotate`.
+// It is not part of the `TypeMeta` interface, so a `TypeMeta` has to be asserted to it, e.g. `Annotate(t.(Annotatable), key, value)`.
+type Annotatable interface {
+	annotationSet(create bool) *annotationSet
+}
+
+// Annotate sets the value of an annotation of a type meta or a struct field. Annotations of struct fields are shared by all copies of the
+// field retrieved from its struct. It panics if a struct field has not been created by a schema.
+func Annotate[T any](target Annotatable, key *Key[T], value T) {
+	annotationsMu.Lock()
+	defer annotationsMu.Unlock()
+	set := target.annotationSet(true)
+	if set.values == nil {
+		set.values = map[interface{}]interface{}{}
+	}
+	set.values[key] = value
+}
+
+// Annotation returns the value of an annotation of a type meta or a struct field, and whether it has been set
+func Annotation[T any](target Annotatable, key *Key[T]) (T, bool) {
+	annotationsMu.RLock()
+	defer annotationsMu.RUnlock()
+	if set := target.annotationSet(false); set != nil {
+		if value, ok := set.values[key]; ok {
+			return value.(T), true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// RemoveAnnotation removes an annotation of a type meta or a struct field
+func RemoveAnnotation[T any](target Annotatable, key *Key[T]) {
+	annotationsMu.Lock()
+	defer annotationsMu.Unlock()
+	if set := target.annotationSet(false); set != nil {
+		delete(set.values, key)
+	}
+}
+
+// annotationSet holds the annotations of a type meta or a struct field. It is guarded by annotationsMu.
+type annotationSet struct {
+	values map[interface{}]interface{}
+}
+
+var annotationsMu sync.RWMutex
+
+// lazyAnnotationSet returns the annotation set, creating it if it is nil and create is true
+func lazyAnnotationSet(set **annotationSet, create bool) *annotationSet {
+	if *set == nil && create {
+		*set = &annotationSet{}
+	}
+	return *set
+}
+
+// copyAnnotated returns a copy of an annotated type meta, read under annotationsMu since its annotation set may be created concurrently by `Annotate`
+func copyAnnotated[T any](meta *T) T {
+	annotationsMu.RLock()
+	defer annotationsMu.RUnlock()
+	return *meta
+}
+
+// clone returns a copy of the annotation set, or nil for a nil annotation set
+func (a *annotationSet) clone() *annotationSet {
+	if a == nil {
+		return nil
+	}
+	annotationsMu.RLock()
+	defer annotationsMu.RUnlock()
+	ca := &annotationSet{}
+	if a.values != nil {
+		ca.values = make(map[interface{}]interface{}, len(a.values))
+		for key, value := range a.values {
+			ca.values[key] = value
+		}
+	}
+	return ca
+}
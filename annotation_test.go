@@ -0,0 +1,65 @@
+package typemeta
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAnnotation(t *testing.T) {
+	type StructA struct {
+		Email string
+	}
+	owner := NewKey[string]("owner")
+	cost := NewKey[int]("cost")
+	structA := NewSchema().GetStruct(StructA{})
+
+	Annotate(structA, owner, "identity")
+	if v, ok := Annotation(structA, owner); !ok || v != "identity" {
+		t.Error("struct should have owner annotation")
+	}
+	if _, ok := Annotation(structA, cost); ok {
+		t.Error("struct should not have cost annotation")
+	}
+	var meta TypeMeta = structA.EnsureFieldByName("Email").TypeMeta
+	if _, ok := Annotation(meta.(Annotatable), owner); ok {
+		t.Error("field type should not have owner annotation")
+	}
+
+	Annotate(structA.Field(0), cost, 5)
+	if v, ok := Annotation(structA.EnsureFieldByName("Email"), cost); !ok || v != 5 {
+		t.Error("annotations of a field should be shared by its copies")
+	}
+
+	copied := structA.Copy()
+	Annotate(copied, owner, "billing")
+	Annotate(copied.Field(0), cost, 10)
+	if v, _ := Annotation(copied, owner); v != "billing" {
+		t.Error("copy should have its own annotations")
+	}
+	if v, _ := Annotation(structA, owner); v != "identity" {
+		t.Error("annotating a copy should not affect the original")
+	}
+	if v, _ := Annotation(structA.Field(0), cost); v != 5 {
+		t.Error("annotating a field of a copy should not affect the original")
+	}
+
+	RemoveAnnotation(structA, owner)
+	if _, ok := Annotation(structA, owner); ok {
+		t.Error("annotation should have been removed")
+	}
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			Annotation(copied, owner)
+		}()
+		go func() {
+			defer wg.Done()
+			meta.(*Primitive).Copy()
+			Annotate(meta.(Annotatable), owner, "copied")
+		}()
+	}
+	wg.Wait()
+}
@@ -6,8 +6,9 @@ import (
 
 // Array is type meta for an array
 type Array struct {
-	Elem TypeMeta
-	typ  reflect.Type
+	Elem        TypeMeta
+	typ         reflect.Type
+	annotations *annotationSet
 }
 
 // Primitive returns true for slices with primitive elements
@@ -38,3 +39,7 @@ func (s *Array) Name() string {
 func (s *Array) String() string {
 	return s.Type().String()
 }
+
+func (s *Array) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -0,0 +1,54 @@
+package typemeta
+
+import (
+	"reflect"
+)
+
+// Chan is type meta for a channel
+type Chan struct {
+	Elem TypeMeta
+	Dir  reflect.ChanDir // Direction of the channel
+
+	typ         reflect.Type
+	annotations *annotationSet
+}
+
+// Type returns the type of the channel
+func (s *Chan) Type() reflect.Type {
+	return s.typ
+}
+
+// Kind returns `reflect.Chan` for channels
+func (s *Chan) Kind() reflect.Kind {
+	return reflect.Chan
+}
+
+// Primitive returns false for channels
+func (s *Chan) Primitive() bool {
+	return false
+}
+
+// JSONNonNull returns false for channels
+func (s *Chan) JSONNonNull() bool {
+	return false
+}
+
+// Name returns the type meta's explicitly set name or the type's name within its package for a defined type. For other (non-defined) types it returns the empty string.
+func (s *Chan) Name() string {
+	return s.typ.Name()
+}
+
+// Copy returns a copy of the chan type meta
+func (s *Chan) Copy() *Chan {
+	ns := copyAnnotated(s)
+	ns.annotations = ns.annotations.clone()
+	return &ns
+}
+
+func (s *Chan) String() string {
+	return s.Type().String()
+}
+
+func (s *Chan) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -0,0 +1,172 @@
+package typemeta
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/base64"
+	"encoding/json"
+	"math"
+	"reflect"
+	"time"
+)
+
+// Codec is an encoding interface which may be implemented by a type or a pointer to it, e.g. `encoding.TextMarshaler`.
+// Codecs are flags, so multiple codecs can be combined using `|`.
+type Codec int
+
+const (
+	JSONMarshalerCodec     Codec = 1 << iota // json.Marshaler
+	JSONUnmarshalerCodec                     // json.Unmarshaler
+	TextMarshalerCodec                       // encoding.TextMarshaler
+	TextUnmarshalerCodec                     // encoding.TextUnmarshaler
+	BinaryMarshalerCodec                     // encoding.BinaryMarshaler
+	BinaryUnmarshalerCodec                   // encoding.BinaryUnmarshaler
+	ScannerCodec                             // sql.Scanner
+	ValuerCodec                              // driver.Valuer
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	valuerType  = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+)
+
+// codecTypes are the interface types of the codecs in the order of their flags
+var codecTypes = []reflect.Type{
+	reflect.TypeOf((*json.Marshaler)(nil)).Elem(),
+	reflect.TypeOf((*json.Unmarshaler)(nil)).Elem(),
+	reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem(),
+	reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem(),
+	reflect.TypeOf((*encoding.BinaryMarshaler)(nil)).Elem(),
+	reflect.TypeOf((*encoding.BinaryUnmarshaler)(nil)).Elem(),
+	scannerType,
+	valuerType,
+}
+
+// String returns the name of the interface of the codec, e.g. "encoding.TextMarshaler", or the names of the combined codecs separated by "|"
+func (c Codec) String() string {
+	str := ""
+	for i, codecType := range codecTypes {
+		if c&(1<<i) == 0 {
+			continue
+		}
+		if str != "" {
+			str += "|"
+		}
+		str += codecType.String()
+	}
+	return str
+}
+
+// Codecs returns the codecs implemented by the primitive type or a pointer to it
+func (s *Primitive) Codecs() []Codec {
+	var codecs []Codec
+	for i := range codecTypes {
+		if codec := Codec(1 << i); s.codecs&codec != 0 {
+			codecs = append(codecs, codec)
+		}
+	}
+	return codecs
+}
+
+// Implements returns whether the primitive type or a pointer to it implements the specified codecs
+func (s *Primitive) Implements(codec Codec) bool {
+	return s.codecs&codec == codec
+}
+
+// codecsOf returns the codecs implemented by a type or a pointer to it
+func codecsOf(rtyp reflect.Type) Codec {
+	var codecs Codec
+	ptrType := reflect.PtrTo(rtyp)
+	for i, codecType := range codecTypes {
+		if rtyp.Implements(codecType) || ptrType.Implements(codecType) {
+			codecs |= 1 << i
+		}
+	}
+	return codecs
+}
+
+// codecPrimitive returns whether a struct, array, or slice type is primitive because of the codecs it implements, i.e. a JSON or text
+// marshaler, `driver.Valuer`, or `sql.Scanner`. Marshalers and valuers have to be implemented by the type itself, since `encoding/json`
+// only uses methods with pointer receivers for addressable values. Values are marshaled using a marshaler or else a valuer, and unmarshaled
+// using an unmarshaler or else a scanner (see `MarshalValue` and `UnmarshalValue`), so types implementing only one of `driver.Valuer` and
+// `sql.Scanner` are still primitive, but only types implementing both, like `sql.NullTime`, round-trip through their driver values.
+func codecPrimitive(rtyp reflect.Type) bool {
+	switch rtyp.Kind() {
+	case reflect.Struct, reflect.Array, reflect.Slice:
+	default:
+		return false
+	}
+	return implementsMarshaler(rtyp) || rtyp.Implements(valuerType) || reflect.PtrTo(rtyp).Implements(scannerType)
+}
+
+// driverValueOf returns the driver value of a value of a primitive type implementing `driver.Valuer` but no marshaler, and whether it has one
+func driverValueOf(value reflect.Value, tm TypeMeta) (interface{}, bool, error) {
+	p, ok := tm.(*Primitive)
+	if !ok || !p.Implements(ValuerCodec) || marshalerOf(value) != nil || !value.CanInterface() {
+		return nil, false, nil
+	}
+	valuer, ok := value.Interface().(driver.Valuer)
+	if !ok {
+		return nil, false, nil
+	}
+	driverValue, err := valuer.Value()
+	return driverValue, true, err
+}
+
+// convertCodecValue converts a value using the codec methods of its type or the type it is converted to: a string is unmarshaled
+// into a type implementing an unmarshaler, values are scanned into types implementing `sql.Scanner`, and values of types implementing
+// `driver.Valuer` but no marshaler are converted through their driver values. Returns false if no codec applies.
+func convertCodecValue(c *conversion, value reflect.Value, valueTypeMeta TypeMeta, toTypeMeta TypeMeta) (reflect.Value, bool, error) {
+	toType := toTypeMeta.Type()
+	if toPrimitive, ok := toTypeMeta.(*Primitive); ok && toPrimitive.codecs != 0 {
+		newValuePtr := reflect.New(toType)
+		if value.Kind() == reflect.String && toPrimitive.Implements(TextUnmarshalerCodec) {
+			err := newValuePtr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(value.String()))
+			return newValuePtr.Elem(), true, err
+		}
+		if toPrimitive.Implements(ScannerCodec) && value.CanInterface() {
+			err := scan(newValuePtr.Interface().(sql.Scanner), value.Interface())
+			return newValuePtr.Elem(), true, err
+		}
+		if unmarshaler := unmarshalerOf(newValuePtr); unmarshaler != nil && value.Kind() == reflect.String {
+			err := unmarshaler([]byte(value.String()))
+			return newValuePtr.Elem(), true, err
+		}
+	}
+	if _, ok := toTypeMeta.(*Primitive); !ok {
+		return value, false, nil
+	}
+	driverValue, ok, err := driverValueOf(value, valueTypeMeta)
+	if !ok {
+		return value, false, nil
+	}
+	if err != nil || driverValue == nil {
+		return reflect.New(toType).Elem(), true, err
+	}
+	newValue, err := convertValue(c, reflect.ValueOf(driverValue), c.get(reflect.TypeOf(driverValue)), toTypeMeta)
+	return newValue, true, err
+}
+
+// scan scans a value into a scanner. Since driver values are marshaled as JSON (see `MarshalValue`), a value which is not accepted by
+// the scanner is scanned as the driver value it may encode: an integer number as int64, and a string as an RFC 3339 time or base64 encoded bytes.
+func scan(scanner sql.Scanner, value interface{}) error {
+	err := scanner.Scan(value)
+	if err == nil {
+		return nil
+	}
+	switch value := value.(type) {
+	case float64:
+		if value == math.Trunc(value) && math.Abs(value) < math.MaxInt64 && scanner.Scan(int64(value)) == nil {
+			return nil
+		}
+	case string:
+		if t, timeErr := time.Parse(time.RFC3339Nano, value); timeErr == nil && scanner.Scan(t) == nil {
+			return nil
+		}
+		if b, bytesErr := base64.StdEncoding.DecodeString(value); bytesErr == nil && scanner.Scan(b) == nil {
+			return nil
+		}
+	}
+	return err
+}
@@ -0,0 +1,170 @@
+package typemeta
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type codecCents struct {
+	units int64
+}
+
+func (c *codecCents) Scan(src interface{}) error {
+	switch src := src.(type) {
+	case int64:
+		c.units = src
+	case string:
+		f, err := strconv.ParseFloat(src, 64)
+		if err != nil {
+			return err
+		}
+		c.units = int64(f * 100)
+	default:
+		return errors.New("cannot scan cents")
+	}
+	return nil
+}
+
+func (c codecCents) Value() (driver.Value, error) {
+	return c.units, nil
+}
+
+// codecVersion only implements `driver.Valuer`
+type codecVersion struct {
+	Major, Minor int
+}
+
+func (v codecVersion) Value() (driver.Value, error) {
+	return strconv.Itoa(v.Major) + "." + strconv.Itoa(v.Minor), nil
+}
+
+// codecChecksum only implements `sql.Scanner`
+type codecChecksum struct {
+	Sum string
+}
+
+func (c *codecChecksum) Scan(src interface{}) error {
+	b, ok := src.([]byte)
+	if !ok {
+		return errors.New("cannot scan checksum")
+	}
+	c.Sum = string(b)
+	return nil
+}
+
+type codecRecord struct {
+	IP    net.IP         `json:"ip"`
+	Price codecCents     `json:"price"`
+	Name  sql.NullString `json:"name"`
+	Count sql.NullInt64  `json:"count"`
+	When  sql.NullTime   `json:"when"`
+}
+
+func TestCodecs(t *testing.T) {
+	s := NewSchema()
+
+	t.Run("primitive", func(t *testing.T) {
+		ip, ok := s.Get(net.IP{}).(*Primitive)
+		if !ok {
+			t.Fatal("expected text marshaler slice to be primitive")
+		}
+		if !ip.Implements(TextMarshalerCodec|TextUnmarshalerCodec) || ip.Implements(ScannerCodec) {
+			t.Error("expected text codecs but received " + ip.codecs.String())
+		}
+		cents, ok := s.Get(codecCents{}).(*Primitive)
+		if !ok {
+			t.Fatal("expected scanner struct to be primitive")
+		}
+		if codecs := cents.Codecs(); len(codecs) != 2 || codecs[0] != ScannerCodec || codecs[1] != ValuerCodec {
+			t.Error("expected scanner and valuer codecs")
+		}
+		if tm, ok := s.Get(time.Time{}).(*Primitive); !ok || !tm.Implements(JSONMarshalerCodec|BinaryUnmarshalerCodec) {
+			t.Error("expected time to be primitive with JSON and binary codecs")
+		}
+		if _, ok := s.Get(codecRecord{}).(*Struct); !ok {
+			t.Error("expected struct without codecs to be a struct")
+		}
+		if version, ok := s.Get(codecVersion{}).(*Primitive); !ok || !version.Implements(ValuerCodec) || version.Implements(ScannerCodec) {
+			t.Error("expected valuer struct to be primitive")
+		}
+		if checksum, ok := s.Get(codecChecksum{}).(*Primitive); !ok || !checksum.Implements(ScannerCodec) || checksum.Implements(ValuerCodec) {
+			t.Error("expected scanner struct to be primitive")
+		}
+		if JSONMarshalerCodec.String() != "json.Marshaler" || (ScannerCodec|ValuerCodec).String() != "sql.Scanner|driver.Valuer" {
+			t.Error("unexpected codec names " + (ScannerCodec | ValuerCodec).String())
+		}
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		ip, err := s.ConvertInterfaceValue("10.0.0.1", net.IP{})
+		if err != nil || !ip.(net.IP).Equal(net.IPv4(10, 0, 0, 1)) {
+			t.Error("expected string to be unmarshaled as IP")
+		}
+		if str, err := s.ConvertInterfaceValue(ip, ""); err != nil || str != "10.0.0.1" {
+			t.Error("expected IP to be marshaled as string")
+		}
+		cents, err := s.ConvertInterfaceValue("1.5", codecCents{})
+		if err != nil || cents.(codecCents).units != 150 {
+			t.Error("expected string to be scanned as cents")
+		}
+		if units, err := s.ConvertInterfaceValue(cents, 0); err != nil || units != 150 {
+			t.Error("expected cents to be converted through their driver value")
+		}
+		if _, err := s.ConvertInterfaceValue(true, codecCents{}); err == nil {
+			t.Error("expected scan error")
+		}
+		if when, err := s.ConvertInterfaceValue("2020-01-01T00:00:00Z", sql.NullTime{}); err != nil || !when.(sql.NullTime).Time.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Error("expected RFC 3339 string to be scanned as time")
+		}
+		if checksum, err := s.ConvertInterfaceValue("YWJj", codecChecksum{}); err != nil || checksum.(codecChecksum).Sum != "abc" {
+			t.Error("expected base64 string to be scanned as bytes")
+		}
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		v, err := s.UnmarshalValue(codecRecord{}, []byte(`{"ip":"::1","price":"2.25","name":"name","count":4294967296,"when":"2020-01-01T00:00:00Z"}`))
+		if err != nil {
+			t.Fatal("failed unmarshaling value: " + err.Error())
+		}
+		record := v.(codecRecord)
+		if !record.IP.Equal(net.IPv6loopback) || record.Price.units != 225 || record.Name != (sql.NullString{String: "name", Valid: true}) || record.Count != (sql.NullInt64{Int64: 4294967296, Valid: true}) || !record.When.Valid || record.When.Time.Year() != 2020 {
+			t.Error("expected values to be decoded using codecs")
+		}
+		data, err := s.MarshalValue(record)
+		expected := `{"ip":"::1","price":225,"name":"name","count":4294967296,"when":"2020-01-01T00:00:00Z"}`
+		if err != nil || string(data) != expected {
+			t.Error("expected " + expected + " but received " + string(data))
+		}
+		v, err = s.UnmarshalValue(codecRecord{}, data)
+		if err != nil {
+			t.Fatal("failed unmarshaling marshaled value: " + err.Error())
+		}
+		if unmarshaled := v.(codecRecord); !unmarshaled.IP.Equal(record.IP) || unmarshaled.Price != record.Price || unmarshaled.Name != record.Name || unmarshaled.Count != record.Count || unmarshaled.When.Valid != record.When.Valid || !unmarshaled.When.Time.Equal(record.When.Time) {
+			t.Error("expected marshaled value to be unmarshaled to the same value")
+		}
+		if data, err := s.MarshalValue(codecRecord{}); err != nil || string(data) != `{"ip":"","price":0,"name":null,"count":null,"when":null}` {
+			t.Error("expected null driver values to be marshaled as null but received " + string(data))
+		} else if v, err := s.UnmarshalValue(codecRecord{}, data); err != nil || v.(codecRecord).IP != nil || v.(codecRecord).Name.Valid || v.(codecRecord).Count.Valid || v.(codecRecord).When.Valid {
+			t.Error("expected marshaled zero value to be unmarshaled to the zero value")
+		}
+		if data, err := s.MarshalValue(codecVersion{1, 2}); err != nil || string(data) != `"1.2"` {
+			t.Error("expected valuer to be marshaled as its driver value but received " + string(data))
+		}
+		if data, err := s.MarshalValue(codecChecksum{"abc"}); err != nil || string(data) != `{"Sum":"abc"}` {
+			t.Error("expected scanner without valuer to be marshaled like by encoding/json but received " + string(data))
+		} else if v, err := s.UnmarshalValue(codecChecksum{}, data); err != nil || v.(codecChecksum).Sum != "abc" {
+			t.Error("expected object to be unmarshaled into scanner without scanning")
+		}
+		if v, err := s.UnmarshalValue(sql.NullString{}, []byte(`"name"`)); err != nil || v.(sql.NullString).String != "name" {
+			t.Error("expected scanned primitive to be unmarshaled")
+		}
+		if v, err := s.UnmarshalValue(net.IP{}, []byte(`"10.0.0.1"`)); err != nil || !v.(net.IP).Equal(net.IPv4(10, 0, 0, 1)) {
+			t.Error("expected text unmarshaler slice to be unmarshaled")
+		}
+	})
+}
@@ -0,0 +1,157 @@
+package typemeta
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// typeComments are the doc comments of a type declaration and the fields of a struct type declaration
+type typeComments struct {
+	doc    string
+	fields map[string]string
+}
+
+// LoadComments parses the Go source files of the package in the specified directory, including test files, and uses the doc comments of
+// struct type declarations and their fields as descriptions of the corresponding struct type metas. Declarations are matched to types by the
+// specified package path, e.g. "example.com/api", and by type name. Descriptions that are already set, e.g. using the `description` tag,
+// are kept. The comments apply both to struct type metas already known to the schema and to those built later. Since the descriptions
+// of known type metas are changed, LoadComments is not safe for concurrent use of the schema, and should be called before it is shared.
+// A field's doc comment is used, or else its line comment.
+func (s *Schema) LoadComments(dir string, pkgPath string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	fset := token.NewFileSet()
+	comments := map[string]typeComments{}
+	var errs Errors
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.ParseComments)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if strings.HasSuffix(file.Name.Name, "_test") {
+			// external test package
+			continue
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				doc := typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				comments[pkgPath+"."+typeSpec.Name.Name] = typeComments{commentText(doc), fieldComments(typeSpec)}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.comments == nil {
+		s.comments = map[string]typeComments{}
+	}
+	for key, tc := range comments {
+		s.comments[key] = tc
+	}
+	for _, t := range s.types {
+		if strct, ok := t.(*Struct); ok {
+			s.applyComments(strct)
+		}
+	}
+	// cached visible fields have the previous descriptions
+	s.fieldsChanged()
+	return nil
+}
+
+// applyComments sets the descriptions of a struct type meta and its fields from loaded comments where they are not set. The schema mutex must be locked.
+func (s *Schema) applyComments(strct *Struct) {
+	if s.comments == nil || strct.typ.Name() == "" {
+		return
+	}
+	typeName := strct.typ.Name()
+	if i := strings.IndexByte(typeName, '['); i >= 0 {
+		// instantiated generic type
+		typeName = typeName[:i]
+	}
+	tc, ok := s.comments[strct.typ.PkgPath()+"."+typeName]
+	if !ok {
+		return
+	}
+	if strct.Description == "" {
+		strct.Description = tc.doc
+	}
+	for fieldIndex, field := range strct.Fields {
+		if field.Description == "" && tc.fields[field.Name] != "" {
+			field.Description = tc.fields[field.Name]
+			strct.Fields[fieldIndex] = field
+		}
+	}
+}
+
+// fieldComments returns the comments of each field of a struct type declaration by field name
+func fieldComments(typeSpec *ast.TypeSpec) map[string]string {
+	fields := map[string]string{}
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		return fields
+	}
+	for _, field := range structType.Fields.List {
+		doc := field.Doc
+		if doc == nil {
+			doc = field.Comment
+		}
+		if doc == nil {
+			continue
+		}
+		if len(field.Names) == 0 {
+			if name := embeddedFieldName(field.Type); name != "" {
+				fields[name] = commentText(doc)
+			}
+		}
+		for _, name := range field.Names {
+			fields[name.Name] = commentText(doc)
+		}
+	}
+	return fields
+}
+
+// embeddedFieldName returns the field name of an embedded field type expression, e.g. "Time" for `*time.Time`
+func embeddedFieldName(expr ast.Expr) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		return expr.Name
+	case *ast.StarExpr:
+		return embeddedFieldName(expr.X)
+	case *ast.SelectorExpr:
+		return expr.Sel.Name
+	case *ast.IndexExpr:
+		return embeddedFieldName(expr.X)
+	case *ast.IndexListExpr:
+		return embeddedFieldName(expr.X)
+	default:
+		return ""
+	}
+}
+
+func commentText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.Text())
+}
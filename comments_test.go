@@ -0,0 +1,63 @@
+package typemeta
+
+import (
+	"reflect"
+	"testing"
+)
+
+// commentsUser is a registered user.
+type commentsUser struct {
+	commentsBase
+	// Email is the primary email address.
+	Email string
+	Name  string `description:"Name from tag"` // Name is not used as description.
+	Age   int    // Age in years.
+}
+
+// commentsBase is embedded in other structs
+type commentsBase struct {
+	ID string
+}
+
+// commentsAudit is an audit log entry.
+type commentsAudit struct {
+	Action string // Action that was performed.
+}
+
+func TestLoadComments(t *testing.T) {
+	s := NewSchema()
+	user := s.GetStruct(commentsUser{})
+	// cached visible fields are invalidated when loading comments
+	user.VisibleFields()
+	if err := s.LoadComments(".", "github.com/ludvigalden/go-typemeta"); err != nil {
+		t.Fatal("failed loading comments: " + err.Error())
+	}
+	if user.Description != "commentsUser is a registered user." {
+		t.Error("struct should be described by its doc comment, received \"" + user.Description + "\"")
+	}
+	if user.EnsureFieldByName("Email").Description != "Email is the primary email address." {
+		t.Error("field should be described by its doc comment")
+	}
+	if user.EnsureFieldByName("Name").Description != "Name from tag" {
+		t.Error("field description from tag should be kept")
+	}
+	if user.EnsureFieldByName("Age").Description != "Age in years." {
+		t.Error("field should be described by its line comment")
+	}
+	if s.GetStruct(commentsBase{}).Description != "commentsBase is embedded in other structs" {
+		t.Error("embedded struct built before loading comments should be described by its doc comment")
+	}
+	s.mu.Lock()
+	_, built := s.types[reflect.TypeOf(commentsAudit{})]
+	s.mu.Unlock()
+	if built {
+		t.Fatal("commentsAudit should not be built before loading comments")
+	}
+	audit := s.GetStruct(commentsAudit{})
+	if audit.Description != "commentsAudit is an audit log entry." || audit.EnsureFieldByName("Action").Description != "Action that was performed." {
+		t.Error("struct built after loading comments should be described by its comments")
+	}
+	if err := s.LoadComments("./nonexistent", "example.com/nonexistent"); err == nil {
+		t.Error("expected an error for a nonexistent directory")
+	}
+}
@@ -0,0 +1,111 @@
+package typemeta
+
+import (
+	"reflect"
+)
+
+// Converter converts and unmarshals values like `ConvertValue` and `UnmarshalValue`, with options. The zero value uses the default schema.
+type Converter struct {
+	Schema *Schema // Schema of the type metas, or nil for the default schema
+	// OnDeprecated is called, if non-nil, whenever a deprecated struct field, struct type, or enum value is used by a converted value,
+	// e.g. to measure the usage of deprecated fields before removing them
+	OnDeprecated func(usage DeprecatedUsage)
+	// ReadOnly is the policy for values of read-only fields when converting maps to structs. By default, the values are ignored.
+	ReadOnly ReadOnlyPolicy
+	// CaseInsensitive matches map keys to struct fields case-insensitively when converting maps to structs (see `Struct.FieldByNameFold`)
+	CaseInsensitive bool
+	// AliasPolicy is the policy for maps with both the name and an alias of a field as keys when converting maps to structs
+	AliasPolicy AliasPolicy
+	// OnAlias is called, if non-nil, whenever an alias of a struct field is used by a converted value, e.g. to measure the usage of old field names
+	OnAlias func(usage AliasUsage)
+}
+
+// DeprecatedUsage is the usage of a deprecated struct field, struct type, or enum value by a converted value
+type DeprecatedUsage struct {
+	Path   string       // Path of the value within the converted value (see `Path.String`), or the empty string for the converted value itself
+	Field  *StructField // Deprecated struct field, if a field is deprecated
+	Struct *Struct      // Deprecated struct type, if a struct type is deprecated
+	Enum   *Enum        // Enum of a deprecated enum value, if an enum value is deprecated
+	Value  string       // Name of the deprecated enum value, if an enum value is deprecated
+	Notice string       // Deprecation notice
+}
+
+// conversion is the state of a single conversion of a converter
+type conversion struct {
+	*Converter
+	schema       *Schema
+	path         Path
+	keyPositions map[uintptr]map[string]int // key positions of objects decoded from JSON by map pointer (see `decodeOrdered`)
+	unmarshaling bool                       // whether the converted value was unmarshaled from JSON (see `unmarshal`)
+	built        map[reflect.Type]TypeMeta  // type metas built but not yet added to the schema, e.g. when parsing tag examples (see `Schema.get`)
+}
+
+func (c *Converter) conversion() *conversion {
+	return &conversion{Converter: c, schema: schemaOf(c.Schema)}
+}
+
+// get returns the type meta of a type from the schema of the conversion
+func (c *conversion) get(typ reflect.Type) TypeMeta {
+	if t := c.built[typ]; t != nil {
+		return t
+	}
+	return c.schema.get(typ, false)
+}
+
+// convertNested converts a value nested at the specified step from the currently converted value, or the converted value itself if the step is nil
+func (c *conversion) convertNested(step *PathStep, value reflect.Value, valueTypeMeta TypeMeta, toTypeMeta TypeMeta) (reflect.Value, error) {
+	pathLen := len(c.path)
+	if step != nil {
+		c.path = append(c.path, *step)
+	}
+	converted, err := convertValue(c, value, valueTypeMeta, toTypeMeta)
+	if err == nil {
+		c.reportDeprecatedValue(converted, toTypeMeta)
+	}
+	c.path = c.path[:pathLen]
+	return converted, err
+}
+
+// reportDeprecatedValue reports the usage of a deprecated struct type or enum value by a value converted to the specified type meta
+func (c *conversion) reportDeprecatedValue(value reflect.Value, toTypeMeta TypeMeta) {
+	if c.OnDeprecated == nil {
+		return
+	}
+	for value.IsValid() && value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+	if !value.IsValid() {
+		return
+	}
+	switch t := NonPtr(toTypeMeta).(type) {
+	case *Struct:
+		if t.Deprecated != "" {
+			c.reportDeprecated(nil, DeprecatedUsage{Struct: t, Notice: t.Deprecated})
+		}
+	case *Primitive:
+		if t.enum == nil || len(t.enum.deprecated) == 0 {
+			return
+		}
+		if name, ok := t.enum.valueName(value.Interface()); ok && t.enum.deprecated[name] != "" {
+			c.reportDeprecated(nil, DeprecatedUsage{Enum: t.enum, Value: name, Notice: t.enum.deprecated[name]})
+		}
+	}
+}
+
+// reportDeprecated calls the deprecation callback with the path of the value nested at the specified step, or the currently converted value if the step is nil
+func (c *conversion) reportDeprecated(step *PathStep, usage DeprecatedUsage) {
+	if c.OnDeprecated == nil {
+		return
+	}
+	usage.Path = c.pathTo(step)
+	c.OnDeprecated(usage)
+}
+
+// pathTo returns the path of the value nested at the specified step from the currently converted value, or of the currently converted value if the step is nil
+func (c *conversion) pathTo(step *PathStep) string {
+	path := c.path
+	if step != nil {
+		path = append(path[:len(path):len(path)], *step)
+	}
+	return path.String()
+}
@@ -0,0 +1,72 @@
+package typemeta
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+type deprecationPlan string
+
+type deprecationAddress struct {
+	Street string `json:"street"`
+	Zip    string `json:"zip" deprecated:"use postalCode instead"`
+}
+
+type deprecationAccount struct {
+	Name      string               `json:"name"`
+	Nickname  string               `json:"nickname" deprecated:""`
+	Plan      deprecationPlan      `json:"plan"`
+	Addresses []deprecationAddress `json:"addresses"`
+}
+
+func TestConverterOnDeprecated(t *testing.T) {
+	s := NewSchema()
+	account := s.GetStruct(deprecationAccount{})
+	s.GetPrimitive(deprecationPlan("")).SetEnum(NewEnum("Plan", []deprecationPlan{"free", "legacy"}).Deprecate("legacy", "use free instead"))
+
+	t.Run("tags", func(t *testing.T) {
+		if notice := account.EnsureFieldByName("nickname").Deprecated; notice != "deprecated" {
+			t.Error("expected nickname field to be deprecated but received notice \"" + notice + "\"")
+		}
+		if notice := s.GetStruct(deprecationAddress{}).EnsureFieldByName("zip").Deprecated; notice != "use postalCode instead" {
+			t.Error("expected zip field to have the tag notice but received \"" + notice + "\"")
+		}
+	})
+
+	t.Run("usages", func(t *testing.T) {
+		usages := []string{}
+		c := &Converter{Schema: s, OnDeprecated: func(usage DeprecatedUsage) {
+			usages = append(usages, usage.Path+": "+usage.Notice)
+		}}
+		_, err := c.UnmarshalValue(deprecationAccount{}, []byte(`{
+			"name": "Ada",
+			"nickname": "ada",
+			"plan": "legacy",
+			"addresses": [{"street": "Main St"}, {"street": "Side St", "zip": "12345"}]
+		}`))
+		if err != nil {
+			t.Fatal("failed unmarshaling value: " + err.Error())
+		}
+		sort.Strings(usages)
+		expected := "addresses[].zip: use postalCode instead, nickname: deprecated, plan: use free instead"
+		if strings.Join(usages, ", ") != expected {
+			t.Error("expected usages \"" + expected + "\" but received \"" + strings.Join(usages, ", ") + "\"")
+		}
+	})
+
+	t.Run("unused", func(t *testing.T) {
+		account.SetDeprecated("use deprecationUser instead")
+		defer account.SetDeprecated("")
+		usages := []DeprecatedUsage{}
+		c := &Converter{Schema: s, OnDeprecated: func(usage DeprecatedUsage) {
+			usages = append(usages, usage)
+		}}
+		if _, err := c.ConvertInterfaceValue(map[string]interface{}{"name": "Ada", "plan": "free"}, deprecationAccount{}); err != nil {
+			t.Fatal("failed converting value: " + err.Error())
+		}
+		if len(usages) != 1 || usages[0].Struct != account || usages[0].Path != "" {
+			t.Error("expected only the deprecated struct to be reported")
+		}
+	})
+}
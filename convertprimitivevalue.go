@@ -1,6 +1,8 @@
 package typemeta
 
 import (
+	"encoding"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -145,35 +147,42 @@ func stringToBytes(s string) []byte {
 	))
 }
 
+// marshalerOf returns the marshaling method of a value implementing `encoding.TextMarshaler`, `json.Marshaler`, or
+// `encoding.BinaryMarshaler`, in that order of preference, or nil if it implements none of them
 func marshalerOf(value reflect.Value) func() ([]byte, error) {
-	marshalerValue := value.MethodByName("MarshalText")
-	if !marshalerValue.IsValid() {
-		marshalerValue = value.MethodByName("MarshalJSON")
+	if !value.CanInterface() {
+		return nil
 	}
-	if !marshalerValue.IsValid() {
-		marshalerValue = value.MethodByName("MarshalBinary")
+	switch marshaler := value.Interface().(type) {
+	case encoding.TextMarshaler:
+		return marshaler.MarshalText
+	case json.Marshaler:
+		return marshaler.MarshalJSON
+	case encoding.BinaryMarshaler:
+		return marshaler.MarshalBinary
 	}
-	if marshalerValue.IsValid() {
-		unmarshaler, ok := marshalerValue.Interface().(func() ([]byte, error))
-		if ok {
-			return unmarshaler
-		}
+	if value.Kind() != reflect.Ptr && value.CanAddr() {
+		return marshalerOf(value.Addr())
 	}
 	return nil
 }
+
+// unmarshalerOf returns the unmarshaling method of a value implementing `encoding.TextUnmarshaler`, `json.Unmarshaler`, or
+// `encoding.BinaryUnmarshaler`, in that order of preference, or nil if it implements none of them
 func unmarshalerOf(value reflect.Value) func([]byte) error {
-	unmarshalerValue := value.MethodByName("UnmarshalText")
-	if !unmarshalerValue.IsValid() {
-		unmarshalerValue = value.MethodByName("UnmarshalJSON")
+	if !value.CanInterface() {
+		return nil
 	}
-	if !unmarshalerValue.IsValid() {
-		unmarshalerValue = value.MethodByName("UnmarshalBinary")
+	switch unmarshaler := value.Interface().(type) {
+	case encoding.TextUnmarshaler:
+		return unmarshaler.UnmarshalText
+	case json.Unmarshaler:
+		return unmarshaler.UnmarshalJSON
+	case encoding.BinaryUnmarshaler:
+		return unmarshaler.UnmarshalBinary
 	}
-	if unmarshalerValue.IsValid() {
-		unmarshaler, ok := unmarshalerValue.Interface().(func([]byte) error)
-		if ok {
-			return unmarshaler
-		}
+	if value.Kind() != reflect.Ptr && value.CanAddr() {
+		return unmarshalerOf(value.Addr())
 	}
 	return nil
 }
@@ -20,30 +20,45 @@ func ConvertInterfaceValue(value interface{}, toType interface{}) (interface{},
 
 // ConvertValue converts a value to a specified type and returns an error if it fails
 func (s *Schema) ConvertValue(value reflect.Value, toType interface{}) (reflect.Value, error) {
+	return (&Converter{Schema: s}).ConvertValue(value, toType)
+}
+
+// ConvertInterfaceValue converts a value to a specified type and returns a detailed error if it fails
+func (s *Schema) ConvertInterfaceValue(value interface{}, toType interface{}) (interface{}, error) {
+	return (&Converter{Schema: s}).ConvertInterfaceValue(value, toType)
+}
+
+// ConvertValue converts a value to a specified type and returns an error if it fails
+func (c *Converter) ConvertValue(value reflect.Value, toType interface{}) (reflect.Value, error) {
+	return c.conversion().convert(value, toType)
+}
+
+// convert converts a value to a specified type as the root of the conversion
+func (c *conversion) convert(value reflect.Value, toType interface{}) (reflect.Value, error) {
 	if !value.IsValid() {
 		return value, errors.New("received invalid value")
 	} else if value.Type() == nil {
 		return value, errors.New("received value with nil type")
 	}
-	return convertValue(s, value, s.Get(value.Type()), s.Get(toType))
+	return c.convertNested(nil, value, c.get(value.Type()), c.schema.Get(toType))
 }
 
 // ConvertInterfaceValue converts a value to a specified type and returns a detailed error if it fails
-func (s *Schema) ConvertInterfaceValue(value interface{}, toType interface{}) (interface{}, error) {
+func (c *Converter) ConvertInterfaceValue(value interface{}, toType interface{}) (interface{}, error) {
 	var rv reflect.Value
 	if vrv, ok := value.(reflect.Value); ok {
 		rv = vrv
 	} else {
 		rv = reflect.ValueOf(value)
 	}
-	cv, err := s.ConvertValue(rv, toType)
+	cv, err := c.ConvertValue(rv, toType)
 	if err != nil {
 		return nil, err
 	}
 	return cv.Interface(), nil
 }
 
-func convertValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta, toTypeMeta TypeMeta) (reflect.Value, error) {
+func convertValue(c *conversion, value reflect.Value, valueTypeMeta TypeMeta, toTypeMeta TypeMeta) (reflect.Value, error) {
 	if !value.IsValid() {
 		return value, errors.New("received invalid value")
 	}
@@ -56,7 +71,7 @@ func convertValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta, toType
 			return value, errors.New("received interface value with nil type")
 		}
 		value = reflect.ValueOf(value.Interface())
-		valueTypeMeta = s.get(value.Type(), false)
+		valueTypeMeta = c.get(value.Type())
 	}
 	toType := toTypeMeta.Type()
 	if value.Type() == toType {
@@ -73,7 +88,7 @@ func convertValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta, toType
 			// parse a pointer to the value (if not equal, keep parsing)
 			newValue := reflect.New(value.Type())
 			newValue.Elem().Set(value)
-			return convertValue(s, newValue, s.Get(newValue.Type()), toTypeMeta)
+			return convertValue(c, newValue, c.get(newValue.Type()), toTypeMeta)
 		}
 		// the value is a pointer, too, but on different levels (e.g. ***string vs. **string or *string vs. **string)
 		// here, we check if the inner type is equal, and in that case returns it
@@ -127,19 +142,32 @@ func convertValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta, toType
 		return nonPtrValue, nil
 	}
 	var err error
-	nonPtrValue, err = convertNonPtrValue(s, nonPtrValue, NonPtr(valueTypeMeta), NonPtr(toTypeMeta))
+	nonPtrValue, err = convertNonPtrValue(c, nonPtrValue, NonPtr(valueTypeMeta), NonPtr(toTypeMeta))
 	if err == nil {
 		if nonPtrValue.Type() == toTypeMeta.Type() || NonPtr(toTypeMeta).Type().Kind() == reflect.Interface {
 			return nonPtrValue, nil
 		}
-		return convertValue(s, nonPtrValue, s.Get(nonPtrValue.Type()), toTypeMeta)
+		return convertValue(c, nonPtrValue, c.get(nonPtrValue.Type()), toTypeMeta)
 	}
 	return value, err
 }
 
-func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta, toTypeMeta TypeMeta) (reflect.Value, error) {
+func convertNonPtrValue(c *conversion, value reflect.Value, valueTypeMeta TypeMeta, toTypeMeta TypeMeta) (reflect.Value, error) {
 	toType := toTypeMeta.Type()
 	newValue := reflect.New(toType).Elem()
+	if scalar := scalarOf(toTypeMeta); scalar != nil && scalar.Parse != nil {
+		return parseScalar(value, toTypeMeta.(*Primitive))
+	}
+	if scalar := scalarOf(valueTypeMeta); scalar != nil && scalar.Serialize != nil {
+		serialized, err := serializeScalar(value, valueTypeMeta.(*Primitive))
+		if err != nil || !serialized.IsValid() {
+			return newValue, err
+		}
+		return convertValue(c, serialized, c.get(serialized.Type()), toTypeMeta)
+	}
+	if codecValue, ok, err := convertCodecValue(c, value, valueTypeMeta, toTypeMeta); ok {
+		return codecValue, err
+	}
 	if toType.Kind() == reflect.String {
 		return convertValueToString(value, valueTypeMeta, toTypeMeta)
 	}
@@ -152,7 +180,7 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 		case *Slice: // slice to slice
 			newValue = reflect.MakeSlice(toType, valueLen, valueLen)
 			for i := 0; i < valueLen; i++ {
-				newElem, err := convertValue(s, value.Index(i), valueTypeMeta.Elem, toTypeMeta.Elem)
+				newElem, err := c.convertNested(&PathStep{Kind: ElemStep}, value.Index(i), valueTypeMeta.Elem, toTypeMeta.Elem)
 				if err != nil {
 					return value, err
 				}
@@ -162,7 +190,7 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 		default:
 			if valueLen == 1 {
 				// try to convert first value
-				newValue, err := ConvertValue(value.Index(0), toTypeMeta)
+				newValue, err := convertValue(c, value.Index(0), valueTypeMeta.Elem, toTypeMeta)
 				if err == nil {
 					return newValue, nil
 				}
@@ -172,7 +200,8 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 				for i := 0; i < valueLen; i++ {
 					strs = append(strs, value.Index(i).String())
 				}
-				newValue, err := ConvertValue(reflect.ValueOf(strings.Join(strs, ", ")), toTypeMeta)
+				joinedValue := reflect.ValueOf(strings.Join(strs, ", "))
+				newValue, err := convertValue(c, joinedValue, c.get(joinedValue.Type()), toTypeMeta)
 				if err == nil {
 					return newValue, nil
 				}
@@ -195,19 +224,44 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 		}
 		switch valueTypeMeta := valueTypeMeta.(type) {
 		case *Map: // map to struct
-			mapIter := value.MapRange()
-			for mapIter.Next() {
-				key := mapIter.Key()
-				structField := toTypeMeta.FieldByName(key.String())
-				if structField == nil {
-					return value, errors.New("unrecognized key \"" + fmt.Sprint(key.Interface()) + "\" does not exist in struct \"" + toTypeMeta.String() + "\"")
+			structKeys, err := c.structKeys(value, toTypeMeta)
+			if err != nil {
+				return value, err
+			}
+			for _, structKey := range structKeys {
+				key, structField := structKey.key, structKey.field
+				if jsonUnsupported(structField.TypeMeta) {
+					return value, errors.New("cannot set key \"" + fmt.Sprint(key.Interface()) + "\" to field \"" + structField.String() + "\" of unsupported type")
+				}
+				if structField.Access == ReadOnly {
+					if c.ReadOnly == RejectReadOnly {
+						return value, errors.New("key \"" + fmt.Sprint(key.Interface()) + "\" cannot be set to read-only field \"" + structField.String() + "\"")
+					} else if c.ReadOnly == IgnoreReadOnly {
+						continue
+					}
 				}
-				keyValue := mapIter.Value()
-				convertedValue, err := convertValue(s, keyValue, valueTypeMeta.Elem, structField.TypeMeta)
+				keyValue := value.MapIndex(key)
+				keyValueTypeMeta := valueTypeMeta.Elem
+				if structField.JSONString && toTypeMeta.nameTag() == "json" {
+					unquotedValue, ok, err := unquoteValue(keyValue, structField.TypeMeta, c.unmarshaling)
+					if err != nil {
+						return value, errors.New("could not convert value of key \"" + fmt.Sprint(key.Interface()) + "\" to field \"" + structField.String() + "\". " + err.Error())
+					} else if ok {
+						keyValue, keyValueTypeMeta = unquotedValue, c.get(unquotedValue.Type())
+					}
+				}
+				step := &PathStep{Kind: FieldStep, Field: structField}
+				if structKey.alias != "" {
+					c.reportAlias(step, AliasUsage{Field: structField, Alias: structKey.alias})
+				}
+				if structField.Deprecated != "" {
+					c.reportDeprecated(step, DeprecatedUsage{Field: structField, Notice: structField.Deprecated})
+				}
+				convertedValue, err := c.convertNested(step, keyValue, keyValueTypeMeta, structField.TypeMeta)
 				if err != nil {
 					return value, errors.New("could not convert value of key \"" + fmt.Sprint(key.Interface()) + "\" to field \"" + structField.String() + "\". " + err.Error())
 				}
-				fieldValue := newValue.Field(structField.Index)
+				fieldValue := fieldByIndex(newValue, structField.indexPath())
 				fieldValue.Set(convertedValue)
 			}
 			return newValue, nil
@@ -233,7 +287,7 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 		case *Slice:
 			newValue = reflect.MakeSlice(toType, value.Len(), value.Cap())
 			for i := 0; i < value.Len(); i++ {
-				convertedElem, err := convertValue(s, value.Index(i), valueTypeMeta.Elem, toTypeMeta.Elem)
+				convertedElem, err := c.convertNested(&PathStep{Kind: ElemStep}, value.Index(i), valueTypeMeta.Elem, toTypeMeta.Elem)
 				if err != nil {
 					return value, err
 				}
@@ -251,7 +305,7 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 			}
 		default: // any element to slice
 			newValue = reflect.MakeSlice(toType, 1, 1)
-			newElemValue, err := convertValue(s, value, valueTypeMeta, toTypeMeta.Elem)
+			newElemValue, err := c.convertNested(&PathStep{Kind: ElemStep}, value, valueTypeMeta, toTypeMeta.Elem)
 			if err != nil {
 				return value, err
 			}
@@ -273,7 +327,7 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 					for mapIter.Next() {
 						key := mapIter.Key()
 						keyValue := mapIter.Value()
-						convertedKeyValue, err := convertValue(s, keyValue, valueTypeMeta.Elem, toTypeMeta.Elem)
+						convertedKeyValue, err := c.convertNested(&PathStep{Kind: ElemStep}, keyValue, valueTypeMeta.Elem, toTypeMeta.Elem)
 						if err != nil {
 							return value, errors.New("could not convert value of key \"" + fmt.Sprint(key.Interface()) + "\" to \"" + toTypeMeta.Elem.String() + "\". " + err.Error())
 						}
@@ -286,11 +340,11 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 				for mapIter.Next() {
 					key := mapIter.Key()
 					keyValue := mapIter.Value()
-					convertedKey, err := convertValue(s, key, valueTypeMeta.Key, toTypeMeta.Key)
+					convertedKey, err := c.convertNested(&PathStep{Kind: KeyStep}, key, valueTypeMeta.Key, toTypeMeta.Key)
 					if err != nil {
 						return value, errors.New("could not convert key \"" + fmt.Sprint(key.Interface()) + "\" to \"" + toTypeMeta.Key.String() + "\". " + err.Error())
 					}
-					convertedKeyValue, err := convertValue(s, keyValue, valueTypeMeta.Elem, toTypeMeta.Elem)
+					convertedKeyValue, err := c.convertNested(&PathStep{Kind: ElemStep}, keyValue, valueTypeMeta.Elem, toTypeMeta.Elem)
 					if err != nil {
 						return value, errors.New("could not convert value of key \"" + fmt.Sprint(key.Interface()) + "\" to \"" + toTypeMeta.Elem.String() + "\". " + err.Error())
 					}
@@ -301,6 +355,8 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 		default:
 			return value, notAssignibleError(valueTypeMeta, toTypeMeta)
 		}
+	case *Chan, *Func, *UnsafePointer:
+		return value, unsupportedTypeError(toTypeMeta)
 	case *Ptr:
 		return value, errors.New("expected non-ptr")
 	default:
@@ -309,10 +365,52 @@ func convertNonPtrValue(s *Schema, value reflect.Value, valueTypeMeta TypeMeta,
 	return newValue, nil
 }
 
+// fieldByIndex returns the nested field of a struct value at the specified index path, allocating nil embedded struct pointers along the way
+func fieldByIndex(value reflect.Value, indexPath []int) reflect.Value {
+	for i, fieldIndex := range indexPath {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				value.Set(reflect.New(value.Type().Elem()))
+			}
+			value = value.Elem()
+		}
+		value = value.Field(fieldIndex)
+	}
+	return value
+}
+
+// unquoteValue parses the value of a field with the `,string` JSON option if it is a string containing a JSON value of the type of the field.
+// Since the option only applies to the JSON encoding of the field, values other than strings are only rejected if strict, i.e. when unmarshaling
+// JSON (see `UnmarshalValue`), and are otherwise converted like values of fields without the option. Values that are not parsed, such as nil,
+// return false.
+func unquoteValue(value reflect.Value, fieldTypeMeta TypeMeta, strict bool) (reflect.Value, bool, error) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return value, false, nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.String {
+		if !strict {
+			return value, false, nil
+		}
+		return value, false, errors.New("expected quoted value for field with the ,string option but received " + value.Type().String())
+	}
+	unquotedValue := reflect.New(NonPtr(fieldTypeMeta).Type())
+	if err := json.Unmarshal([]byte(value.String()), unquotedValue.Interface()); err != nil {
+		return value, false, errors.New("invalid quoted value \"" + value.String() + "\": " + err.Error())
+	}
+	return unquotedValue.Elem(), true, nil
+}
+
 func notAssignibleError(valueTypeMeta TypeMeta, toTypeMeta TypeMeta) error {
 	return errors.New(valueTypeMeta.String() + " not assignable to " + toTypeMeta.String())
 }
 
+func unsupportedTypeError(toTypeMeta TypeMeta) error {
+	return errors.New("cannot convert to unsupported type " + toTypeMeta.String())
+}
+
 func valueNotAssignibleError(value reflect.Value, toTypeMeta TypeMeta) error {
 	return errors.New(value.String() + " not assignable to " + toTypeMeta.String())
 }
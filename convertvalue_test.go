@@ -3,6 +3,7 @@ package typemeta
 import (
 	"reflect"
 	"testing"
+	"unsafe"
 )
 
 func TestConvertValue(t *testing.T) {
@@ -40,3 +41,31 @@ func TestConvertValue(t *testing.T) {
 		}
 	})
 }
+
+func TestConvertValueUnsupportedTypes(t *testing.T) {
+	type StructA struct {
+		Name     string
+		Done     chan struct{}
+		Callback func()
+		Ptr      unsafe.Pointer
+	}
+	structA := GetStruct(StructA{})
+	for _, fieldName := range []string{"Done", "Callback", "Ptr"} {
+		field := structA.EnsureFieldByName(fieldName)
+		if !field.JSONExcluded || field.Primitive() || field.TypeMeta.JSONNonNull() {
+			t.Error(fieldName + " should be JSON excluded, non-primitive, and nullable")
+		}
+	}
+	if ch, ok := structA.EnsureFieldByName("Done").TypeMeta.(*Chan); !ok || ch.Dir != reflect.BothDir || ch.Elem != Get(struct{}{}) {
+		t.Error("Done should have chan type meta")
+	}
+	if _, ok := structA.EnsureFieldByName("Ptr").TypeMeta.(*UnsafePointer); !ok {
+		t.Error("Ptr should have unsafe pointer type meta")
+	}
+	if _, err := ConvertInterfaceValue(map[string]interface{}{"Done": 1}, StructA{}); err == nil {
+		t.Error("converting to a chan field should fail")
+	}
+	if _, err := UnmarshalValue(Get((chan int)(nil)), []byte("1")); err == nil {
+		t.Error("unmarshaling into a chan should fail")
+	}
+}
@@ -0,0 +1,123 @@
+package typemeta
+
+// Dependencies returns the named type metas that the specified type directly depends on, i.e. the named types that are reachable
+// from the type without passing through another named type. A type depending on itself is included. The type metas are sorted by qualified name.
+func (s *Schema) Dependencies(typ interface{}) []TypeMeta {
+	return dependencies(s.Get(typ))
+}
+
+// Dependents returns the named type metas of the schema that directly depend on the specified type, sorted by qualified name
+func (s *Schema) Dependents(typ interface{}) []TypeMeta {
+	t := s.Get(typ)
+	dependents := []TypeMeta{}
+	for _, nt := range s.namedTypes() {
+		for _, dependency := range dependencies(nt) {
+			if dependency == t {
+				dependents = append(dependents, nt)
+				break
+			}
+		}
+	}
+	return dependents
+}
+
+// TopologicalOrder returns the named type metas reachable from the specified types ordered so that dependencies come before the types
+// depending on them. Each element is a strongly connected component, i.e. a group of recursive types depending on each other, sorted by qualified
+// name, or a single type. If no types are specified, all named type metas of the schema are ordered. The order is deterministic for the same input.
+func (s *Schema) TopologicalOrder(roots ...interface{}) [][]TypeMeta {
+	var rootTypes []TypeMeta
+	if len(roots) == 0 {
+		rootTypes = s.namedTypes()
+	} else {
+		for _, root := range roots {
+			t := s.Get(root)
+			if isNamed(t) {
+				rootTypes = append(rootTypes, t)
+			} else {
+				rootTypes = append(rootTypes, dependencies(t)...)
+			}
+		}
+	}
+	o := topologicalOrdering{
+		dependencies: map[TypeMeta][]TypeMeta{},
+		index:        map[TypeMeta]int{},
+		lowlink:      map[TypeMeta]int{},
+		onStack:      map[TypeMeta]bool{},
+	}
+	for _, t := range rootTypes {
+		if _, ok := o.index[t]; !ok {
+			o.strongConnect(t)
+		}
+	}
+	return o.components
+}
+
+// topologicalOrdering finds the strongly connected components of a type graph using Tarjan's algorithm,
+// which finds each component only after the components it depends on
+type topologicalOrdering struct {
+	dependencies map[TypeMeta][]TypeMeta
+	index        map[TypeMeta]int
+	lowlink      map[TypeMeta]int
+	onStack      map[TypeMeta]bool
+	stack        []TypeMeta
+	components   [][]TypeMeta
+}
+
+func (o *topologicalOrdering) strongConnect(t TypeMeta) {
+	o.index[t] = len(o.index)
+	o.lowlink[t] = o.index[t]
+	o.stack = append(o.stack, t)
+	o.onStack[t] = true
+
+	deps, ok := o.dependencies[t]
+	if !ok {
+		deps = dependencies(t)
+		o.dependencies[t] = deps
+	}
+	for _, dep := range deps {
+		if _, ok := o.index[dep]; !ok {
+			o.strongConnect(dep)
+			if o.lowlink[dep] < o.lowlink[t] {
+				o.lowlink[t] = o.lowlink[dep]
+			}
+		} else if o.onStack[dep] && o.index[dep] < o.lowlink[t] {
+			o.lowlink[t] = o.index[dep]
+		}
+	}
+
+	if o.lowlink[t] == o.index[t] {
+		component := []TypeMeta{}
+		for {
+			member := o.stack[len(o.stack)-1]
+			o.stack = o.stack[:len(o.stack)-1]
+			delete(o.onStack, member)
+			component = append(component, member)
+			if member == t {
+				break
+			}
+		}
+		sortTypes(component)
+		o.components = append(o.components, component)
+	}
+}
+
+func dependencies(t TypeMeta) []TypeMeta {
+	deps := []TypeMeta{}
+	Walk(t, VisitorFuncs{
+		EnterFunc: func(nt TypeMeta, path Path) error {
+			if len(path) > 0 && isNamed(nt) {
+				deps = append(deps, nt)
+				return SkipType
+			}
+			return nil
+		},
+		BackEdgeFunc: func(nt TypeMeta, path Path) error {
+			if nt == t && isNamed(t) {
+				deps = append(deps, t)
+			}
+			return nil
+		},
+	})
+	sortTypes(deps)
+	return deps
+}
@@ -0,0 +1,57 @@
+package typemeta
+
+import (
+	"fmt"
+	"testing"
+)
+
+type depsOrder struct {
+	Customer *depsCustomer
+	Items    []depsItem
+}
+
+type depsCustomer struct {
+	Orders  []*depsOrder
+	Address depsAddress
+}
+
+type depsItem struct {
+	SKU string
+}
+
+type depsAddress struct {
+	Street string
+}
+
+func TestDependencies(t *testing.T) {
+	s := NewSchema()
+	names := func(types []TypeMeta) string {
+		strs := []string{}
+		for _, t := range types {
+			strs = append(strs, t.Name())
+		}
+		return fmt.Sprint(strs)
+	}
+	if deps := s.Dependencies(depsOrder{}); names(deps) != "[depsCustomer depsItem]" {
+		t.Error("unexpected dependencies of depsOrder " + names(deps))
+	}
+	if deps := s.Dependents(depsOrder{}); names(deps) != "[depsCustomer]" {
+		t.Error("unexpected dependents of depsOrder " + names(deps))
+	}
+	if deps := s.Dependencies(walkNode{}); names(deps) != "[walkMeta walkNode]" {
+		t.Error("expected walkNode to depend on itself but received " + names(deps))
+	}
+
+	order := []string{}
+	for _, component := range s.TopologicalOrder([]depsOrder{}) {
+		order = append(order, names(component))
+	}
+	if fmt.Sprint(order) != "[[depsAddress] [depsItem] [depsCustomer depsOrder]]" {
+		t.Error("unexpected topological order " + fmt.Sprint(order))
+	}
+	for i := 0; i < 10; i++ {
+		if len(s.TopologicalOrder()) != 5 {
+			t.Fatal("expected all named types of the schema to be ordered")
+		}
+	}
+}
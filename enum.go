@@ -8,10 +8,12 @@ import (
 
 // Enum is type meta for an enum
 type Enum struct {
-	name     string
-	typ      reflect.Type
-	values   map[string]interface{}
-	firstKey string
+	name       string
+	typ        reflect.Type
+	values     map[string]interface{}
+	firstKey   string
+	labels     map[string]string
+	deprecated map[string]string
 }
 
 // NewEnum creates a new enum with the specified name. The value can be an array of values, which will be converted to a map[string]string (using fmt.Sprint if the values are not strings),
@@ -65,7 +67,7 @@ func NewEnum(name string, v interface{}) *Enum {
 	if typ == nil {
 		typ = reflect.TypeOf(string(""))
 	}
-	return &Enum{name, typ, values, firstKey}
+	return &Enum{name: name, typ: typ, values: values, firstKey: firstKey}
 }
 
 // SetValue sets a value of the enum
@@ -78,6 +80,39 @@ func (e Enum) SetValue(name string, value interface{}) Enum {
 	return e
 }
 
+// SetLabel sets a human-readable label of a value of the enum (for API schemas etc.)
+func (e *Enum) SetLabel(name string, label string) *Enum {
+	if e.labels == nil {
+		e.labels = map[string]string{}
+	}
+	e.labels[name] = label
+	return e
+}
+
+// Label returns the label of a value of the enum, or the empty string if none has been set
+func (e Enum) Label(name string) string {
+	return e.labels[name]
+}
+
+// Deprecate sets the deprecation notice of a value of the enum, e.g. "use fooV2 instead"
+func (e *Enum) Deprecate(name string, notice string) *Enum {
+	if e.deprecated == nil {
+		e.deprecated = map[string]string{}
+	}
+	e.deprecated[name] = notice
+	return e
+}
+
+// Deprecated returns the deprecation notice of a value of the enum, or the empty string unless the value is deprecated
+func (e Enum) Deprecated(name string) string {
+	return e.deprecated[name]
+}
+
+// Name returns the name of the enum
+func (e Enum) Name() string {
+	return e.name
+}
+
 // IterateValues iterates the values of the enum
 func (e Enum) IterateValues(iteratee func(string, interface{})) {
 	if e.values == nil {
@@ -155,6 +190,17 @@ func (e Enum) Parse(value interface{}) (interface{}, bool) {
 	return nil, false
 }
 
+// valueName returns the name of the enum value equal to the specified value
+func (e Enum) valueName(value interface{}) (string, bool) {
+	str := fmt.Sprint(value)
+	for name, enumValue := range e.values {
+		if fmt.Sprint(enumValue) == str {
+			return name, true
+		}
+	}
+	return "", false
+}
+
 // Has returns whether the enum matches a value
 func (e Enum) Has(value interface{}) bool {
 	_, ok := e.Parse(value)
@@ -0,0 +1,45 @@
+package typemeta
+
+import (
+	"strings"
+)
+
+// Errors is a list of errors reported together
+type Errors []error
+
+func (e Errors) Error() string {
+	strs := make([]string, len(e))
+	for i, err := range e {
+		strs[i] = err.Error()
+	}
+	return strings.Join(strs, "; ")
+}
+
+// Unwrap returns the errors of the list
+func (e Errors) Unwrap() []error {
+	return e
+}
+
+// FieldError is an error related to a field of a struct
+type FieldError struct {
+	Struct *Struct
+	Field  string
+	Err    error
+}
+
+func (e *FieldError) Error() string {
+	return "field \"" + e.Field + "\" of struct \"" + e.Struct.String() + "\": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// errorsOrNil returns nil for an empty list of errors, and the list otherwise
+func errorsOrNil(errs Errors) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,54 @@
+package typemeta
+
+import (
+	"errors"
+	"reflect"
+)
+
+// tagValue is a value of a field from an `example` or `default` tag, which is parsed once the type meta of the field is complete
+type tagValue struct {
+	strct      *Struct
+	fieldIndex int
+	key        string
+	value      string
+}
+
+// addTagValue adds the parsed example value or sets the parsed default value of a field of a built struct, or reports an error if the
+// value cannot be parsed as the type of the field
+func (s *Schema) addTagValue(b *unpublished, v tagValue) {
+	field := v.strct.Fields[v.fieldIndex]
+	parsedValue, err := s.parseTagValue(b, field.TypeMeta, v.value)
+	if err != nil {
+		b.errs = append(b.errs, &FieldError{v.strct, field.Name, errors.New("invalid " + v.key + " \"" + v.value + "\": " + err.Error())})
+		return
+	}
+	if v.key == "default" {
+		field.DefaultValue = parsedValue
+	} else {
+		field.Examples = append(field.Examples[:len(field.Examples):len(field.Examples)], parsedValue)
+	}
+	v.strct.Fields[v.fieldIndex] = field
+}
+
+// parseTagValue parses an example or default value of the specified type meta. Values of primitive types are converted from the string,
+// and values of other types, such as structs, slices, and maps, are unmarshaled from JSON. The unpublished type metas are used
+// for types that are not yet known to the schema.
+func (s *Schema) parseTagValue(b *unpublished, tm TypeMeta, example string) (interface{}, error) {
+	c := (&Converter{Schema: s, ReadOnly: AllowReadOnly}).conversion()
+	c.built = b.types
+	switch NonPtr(tm).(type) {
+	case *Primitive, *Interface:
+		exampleValue := reflect.ValueOf(example)
+		convertedExample, err := c.convertNested(nil, exampleValue, c.get(exampleValue.Type()), tm)
+		if err != nil {
+			return nil, err
+		}
+		return convertedExample.Interface(), nil
+	default:
+		unmarshaledExample, err := c.unmarshal(tm, []byte(example))
+		if err != nil {
+			return nil, err
+		}
+		return unmarshaledExample.Interface(), nil
+	}
+}
@@ -0,0 +1,129 @@
+package typemeta
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type exampleProduct struct {
+	Name  string            `json:"name" example:"Chair" example:"Table"`
+	Price *float64          `json:"price" example:"19.99"`
+	Tags  []string          `json:"tags" example:"[\"wood\",\"indoor\"]"`
+	Attrs map[string]string `json:"attrs" example:"{\"color\":\"oak\"}"`
+	Owner *exampleOwner     `json:"owner"`
+}
+
+type exampleOwner struct {
+	Name string `json:"name" example:"Ada"`
+	Age  int    `json:"age" example:"many"`
+}
+
+func TestExamples(t *testing.T) {
+	s := NewSchema()
+	err := s.Register(exampleProduct{})
+	product := s.GetStruct(exampleProduct{})
+
+	t.Run("tags", func(t *testing.T) {
+		if name := product.EnsureFieldByName("name"); fmt.Sprint(name.Examples) != "[Chair Table]" {
+			t.Error("expected name examples [Chair Table] but received " + fmt.Sprint(name.Examples))
+		}
+		price := product.EnsureFieldByName("price")
+		if len(price.Examples) != 1 || *price.Examples[0].(*float64) != 19.99 {
+			t.Error("expected price example to be parsed as *float64")
+		}
+		if tags := product.EnsureFieldByName("tags"); len(tags.Examples) != 1 || fmt.Sprint(tags.Examples[0].([]string)) != "[wood indoor]" {
+			t.Error("expected tags example to be unmarshaled as []string")
+		}
+		if attrs := product.EnsureFieldByName("attrs"); len(attrs.Examples) != 1 || attrs.Examples[0].(map[string]string)["color"] != "oak" {
+			t.Error("expected attrs example to be unmarshaled as map[string]string")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var fieldErr *FieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Field != "Age" {
+			t.Error("expected invalid example of nested struct to be reported when registering")
+		}
+		if age := s.GetStruct(exampleOwner{}).EnsureFieldByName("age"); len(age.Examples) != 0 {
+			t.Error("expected invalid example to be left out")
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		owner := s.GetStruct(exampleOwner{}).SetExample(map[string]interface{}{"name": "Ada", "age": 36})
+		if len(owner.Examples) != 1 || owner.Examples[0] != (exampleOwner{Name: "Ada", Age: 36}) {
+			t.Error("expected struct example to be converted to the struct type")
+		}
+		defer func() {
+			if recover() == nil {
+				t.Error("expected invalid struct example to panic")
+			}
+		}()
+		owner.SetExample(map[string]interface{}{"age": "many"})
+	})
+}
+
+type exampleFlags struct {
+	Enabled bool `json:"enabled" example:"2"`
+	Limit   int  `json:"limit" example:"10"`
+}
+
+func TestExamplesConcurrent(t *testing.T) {
+	s := NewSchema()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			product := s.GetStruct(exampleProduct{})
+			if name := product.EnsureFieldByName("name"); len(name.Examples) != 2 {
+				t.Error("expected examples to be parsed once the struct can be retrieved")
+			}
+			if enabled := DefaultSchema.GetStruct(exampleFlags{}).EnsureFieldByName("enabled"); len(enabled.Examples) != 1 || enabled.Examples[0] != true {
+				t.Error("expected examples of the default schema to be parsed")
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+type exampleMoney struct {
+	cents int
+}
+
+type exampleOrder struct {
+	Total exampleMoney  `json:"total" example:"1.50"`
+	Next  *exampleOrder `json:"next" example:"{\"total\":\"2\"}"`
+}
+
+func TestExamplesParsedUnlocked(t *testing.T) {
+	s := NewSchema()
+	s.RegisterScalar(exampleMoney{}, ScalarSpec{
+		Parse: func(value interface{}) (interface{}, error) {
+			// parsers may use the schema, since examples are parsed without locking it
+			if len(s.GetStruct(exampleFlags{}).EnsureFieldByName("limit").Examples) != 1 {
+				return nil, errors.New("expected examples of other structs to be parsed")
+			}
+			str, _ := value.(string)
+			f, err := strconv.ParseFloat(str, 64)
+			return exampleMoney{int(f * 100)}, err
+		},
+	})
+	generation := s.fieldsGeneration()
+	order := s.GetStruct(exampleOrder{})
+	if err := s.Err(); err != nil {
+		t.Fatal("failed parsing examples: " + err.Error())
+	}
+	if total := order.EnsureFieldByName("total"); len(total.Examples) != 1 || total.Examples[0] != (exampleMoney{150}) {
+		t.Error("expected total example to be parsed by the scalar parser")
+	}
+	if next := order.EnsureFieldByName("next"); len(next.Examples) != 1 || next.Examples[0].(*exampleOrder).Total != (exampleMoney{200}) {
+		t.Error("expected example of recursive field to be parsed")
+	}
+	if s.fieldsGeneration() != generation {
+		t.Error("expected parsed examples not to invalidate cached fields")
+	}
+}
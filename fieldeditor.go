@@ -0,0 +1,110 @@
+package typemeta
+
+// FieldEditor edits the metadata of a struct field, e.g. for types from other modules which cannot be tagged. Edits are visible to
+// all consumers of the struct type meta, and should be made before the type meta is used concurrently.
+type FieldEditor struct {
+	strct *Struct
+	index int
+}
+
+// EditField returns an editor of the field with the specified name. The specified name can either be the struct field name or the JSON field name.
+// If no matching field is found, or the field is promoted from an embedded struct, EditField panics. Since the type meta of an embedded struct
+// is shared by all structs embedding it, promoted fields have to be edited in the embedded struct, affecting all structs embedding it.
+func (s *Struct) EditField(fieldName string) *FieldEditor {
+	field := s.EnsureFieldByName(fieldName)
+	if indexPath := field.indexPath(); len(indexPath) > 1 {
+		embedded := s.Fields[indexPath[0]]
+		panic("Field \"" + fieldName + "\" of " + s.String() + " is promoted from embedded field \"" + embedded.Name + "\" and has to be edited in " + StructOf(embedded.TypeMeta).String())
+	}
+	return &FieldEditor{s, field.Index}
+}
+
+// Field returns the edited field
+func (e *FieldEditor) Field() StructField {
+	return e.strct.Fields[e.index]
+}
+
+// Describe sets the description of the field
+func (e *FieldEditor) Describe(description string) *FieldEditor {
+	return e.edit(func(field *StructField) {
+		field.Description = description
+	})
+}
+
+// Default sets the default value of the field. The value is converted to the type of the field, and Default panics if it cannot be converted.
+func (e *FieldEditor) Default(defaultValue interface{}) *FieldEditor {
+	field := e.Field()
+	convertedDefaultValue, err := (&Converter{Schema: e.strct.schema, ReadOnly: AllowReadOnly}).ConvertInterfaceValue(defaultValue, field.TypeMeta)
+	if err != nil {
+		panic("Invalid default value of field \"" + field.String() + "\": " + err.Error())
+	}
+	return e.edit(func(field *StructField) {
+		field.DefaultValue = convertedDefaultValue
+	})
+}
+
+// JSONName sets the JSON name of the field, including it in JSON unless it is private
+func (e *FieldEditor) JSONName(jsonName string) *FieldEditor {
+	return e.edit(func(field *StructField) {
+		field.JSONName = jsonName
+		field.JSONExcluded = field.Private || jsonUnsupported(field.TypeMeta)
+		field.jsonNamed = true
+	})
+}
+
+// Exclude excludes the field from JSON
+func (e *FieldEditor) Exclude() *FieldEditor {
+	return e.edit(func(field *StructField) {
+		field.JSONName = ""
+		field.JSONExcluded = true
+		field.jsonNamed = false
+	})
+}
+
+// Deprecate sets the deprecation notice of the field, e.g. "use fooV2 instead"
+func (e *FieldEditor) Deprecate(notice string) *FieldEditor {
+	return e.edit(func(field *StructField) {
+		field.Deprecated = notice
+	})
+}
+
+// Access sets the access mode of the field, e.g. `ReadOnly`
+func (e *FieldEditor) Access(access Access) *FieldEditor {
+	return e.edit(func(field *StructField) {
+		field.Access = access
+	})
+}
+
+// Sensitive marks the field as sensitive, meaning that its value is masked by `Redact`
+func (e *FieldEditor) Sensitive() *FieldEditor {
+	return e.edit(func(field *StructField) {
+		field.Sensitive = true
+	})
+}
+
+// Alias adds alternative names of the field, e.g. old JSON names
+func (e *FieldEditor) Alias(aliases ...string) *FieldEditor {
+	return e.edit(func(field *StructField) {
+		field.Aliases = append(field.Aliases[:len(field.Aliases):len(field.Aliases)], aliases...)
+	})
+}
+
+// Example adds an example value of the field. The value is converted to the type of the field, and Example panics if it cannot be converted.
+func (e *FieldEditor) Example(example interface{}) *FieldEditor {
+	field := e.Field()
+	convertedExample, err := (&Converter{Schema: e.strct.schema, ReadOnly: AllowReadOnly}).ConvertInterfaceValue(example, field.TypeMeta)
+	if err != nil {
+		panic("Invalid example of field \"" + field.String() + "\": " + err.Error())
+	}
+	return e.edit(func(field *StructField) {
+		field.Examples = append(field.Examples[:len(field.Examples):len(field.Examples)], convertedExample)
+	})
+}
+
+func (e *FieldEditor) edit(edit func(field *StructField)) *FieldEditor {
+	field := e.strct.Fields[e.index]
+	edit(&field)
+	e.strct.Fields[e.index] = field
+	schemaOf(e.strct.schema).fieldsChanged()
+	return e
+}
@@ -0,0 +1,92 @@
+package typemeta
+
+import (
+	"strings"
+)
+
+// StructFieldPath is the chain of struct fields found at a field path
+type StructFieldPath struct {
+	Fields []StructField // Struct fields along the path, starting at the root
+	Index  []int         // Equivalent index path, as accepted by `StructFieldAt`
+}
+
+// Field returns the last struct field of the path
+func (p StructFieldPath) Field() StructField {
+	return p.Fields[len(p.Fields)-1]
+}
+
+func (p StructFieldPath) String() string {
+	names := make([]string, len(p.Fields))
+	for i, field := range p.Fields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ".")
+}
+
+// FieldByPath returns the chain of fields at the specified dot-separated path, e.g. "address.street" or "orders[].items[].sku".
+// See `FieldPath`. If no matching field is found, nil is returned.
+func (s *Struct) FieldByPath(path string) *StructFieldPath {
+	return FieldPath(s, path)
+}
+
+// FieldPath returns the chain of struct fields at the specified dot-separated path, e.g. "address.street" or "orders[].items[].sku".
+// Each segment of the path can either be a struct field name or a JSON field name. Element type meta of pointers, slices, arrays, and maps is
+// walked through implicitly, but each "[]" suffix of a segment requires the field to be (a pointer to) a slice, array, or map, and descends into its element.
+// A path starting with "[]" descends into the element of the passed type meta itself. If no matching field is found, nil is returned.
+func FieldPath(t TypeMeta, path string) *StructFieldPath {
+	if path == "" {
+		return nil
+	}
+	p := &StructFieldPath{}
+	for _, segment := range strings.Split(path, ".") {
+		name := strings.TrimRight(segment, "[]")
+		elemLevels := strings.Count(segment[len(name):], "[]")
+		if len(name)+elemLevels*2 != len(segment) {
+			// malformed brackets
+			return nil
+		}
+		if name == "" {
+			if len(p.Fields) > 0 || elemLevels == 0 {
+				return nil
+			}
+		} else {
+			st := StructOf(t)
+			if st == nil {
+				return nil
+			}
+			field := st.FieldByName(name)
+			if field == nil {
+				return nil
+			}
+			p.Fields = append(p.Fields, *field)
+			p.Index = append(p.Index, field.indexPath()...)
+			t = field.TypeMeta
+		}
+		for i := 0; i < elemLevels; i++ {
+			switch nt := NonPtr(t).(type) {
+			case *Slice:
+				t = nt.Elem
+			case *Array:
+				t = nt.Elem
+			case *Map:
+				t = nt.Elem
+			default:
+				return nil
+			}
+		}
+	}
+	if len(p.Fields) == 0 {
+		return nil
+	}
+	return p
+}
+
+// EnsureFieldPath returns the chain of struct fields at the specified path. See `FieldPath`.
+// If no matching field is found, EnsureFieldPath panics.
+func EnsureFieldPath(t TypeMeta, path string) StructFieldPath {
+	p := FieldPath(t, path)
+	if p == nil {
+		panic("No struct field of " + t.String() + " found at path \"" + path + "\"")
+	}
+	return *p
+}
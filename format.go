@@ -0,0 +1,92 @@
+package typemeta
+
+import (
+	"github.com/fatih/structtag"
+)
+
+// FieldFormat is the metadata of a struct field for an encoding format, as in the tag of the format, e.g. `yaml:"name,omitempty"`
+type FieldFormat struct {
+	Name      string   // Name of the field in the format
+	Tagged    bool     // Whether the name was explicitly set with a tag, rather than derived from the field name
+	Excluded  bool     // Whether the field is excluded from the format, i.e. private or tagged "-"
+	OmitEmpty bool     // Whether the field is omitted when empty
+	Options   []string // Options of the tag, e.g. "inline" for `yaml:",inline"`
+}
+
+// formatTags are the tags of the formats parsed into `StructField.Formats` when a struct is built by a schema
+var formatTags = []string{"yaml", "xml", "bson", "msgpack", "form", "db"}
+
+// Format returns the metadata of the field for the format of the specified tag, e.g. "yaml". The metadata of "json" is that of the
+// JSON fields of the field, and the metadata of tags other than those in `Formats` is parsed from the tags of the field. Fields untagged in a
+// format other than JSON have their Go field name in that format, since naming strategies only apply to JSON names (see `Schema.SetNamingStrategy`).
+func (sf StructField) Format(tag string) FieldFormat {
+	if tag == "json" {
+		format := FieldFormat{Name: sf.JSONName, Tagged: sf.jsonTagged(), Excluded: sf.JSONExcluded, OmitEmpty: sf.JSONOmitEmpty}
+		if jsonTag := sf.Tag("json"); jsonTag != nil {
+			format.Options = jsonTag.Options
+		}
+		return format
+	}
+	if format, ok := sf.Formats[tag]; ok {
+		return format
+	}
+	tags := sf.Tags
+	if tags == nil {
+		tags = &structtag.Tags{}
+	}
+	return parseFieldFormat(tags, tag, sf.Name, sf.Private)
+}
+
+// NameFor returns the name of the field in the format of the specified tag, e.g. "yaml" (see `Format`)
+func (sf StructField) NameFor(tag string) string {
+	return sf.Format(tag).Name
+}
+
+// OmitEmptyFor returns whether the field is omitted when empty in the format of the specified tag, e.g. "bson" (see `Format`)
+func (sf StructField) OmitEmptyFor(tag string) bool {
+	return sf.Format(tag).OmitEmpty
+}
+
+// ExcludedFor returns whether the field is excluded from the format of the specified tag, e.g. "db" (see `Format`)
+func (sf StructField) ExcludedFor(tag string) bool {
+	return sf.Format(tag).Excluded
+}
+
+// parseFieldFormat parses the metadata of a field for the format of the specified tag. Untagged fields get the field name unless they are private.
+func parseFieldFormat(tags *structtag.Tags, key string, fieldName string, private bool) FieldFormat {
+	format := FieldFormat{Excluded: private}
+	if !private {
+		format.Name = fieldName
+	}
+	tag, _ := tags.Get(key)
+	if tag == nil {
+		return format
+	}
+	if tag.Name == "-" && len(tag.Options) == 0 {
+		format.Name = ""
+		format.Excluded = true
+	} else if tag.Name != "" {
+		format.Name = tag.Name
+		format.Tagged = true
+	}
+	format.OmitEmpty = tag.HasOption("omitempty")
+	format.Options = tag.Options
+	return format
+}
+
+// SetNameTag sets the tag of the format used to match field names, e.g. "yaml" for a schema of YAML configs. The names of the format are
+// used by `Struct.FieldByName`, `Struct.VisibleFields`, and map to struct conversion (see `ConvertValue`). The default tag is "json".
+func (s *Schema) SetNameTag(tag string) *Schema {
+	s.nameTag.Store(tag)
+	s.fieldsChanged()
+	return s
+}
+
+// NameTag returns the tag of the format used to match field names (see `SetNameTag`). It does not lock the schema, since it is used when
+// looking up fields by name.
+func (s *Schema) NameTag() string {
+	if tag, ok := s.nameTag.Load().(string); ok && tag != "" {
+		return tag
+	}
+	return "json"
+}
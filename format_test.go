@@ -0,0 +1,75 @@
+package typemeta
+
+import (
+	"testing"
+)
+
+type formatBase struct {
+	Region string `yaml:"region" bson:"region"`
+}
+
+type formatDocument struct {
+	formatBase
+	ID       string `json:"id" bson:"_id,omitempty" db:"id"`
+	Title    string `json:"title" yaml:"name" xml:"Title,attr"`
+	Internal string `json:"internal" yaml:"-" form:"-"`
+	Count    int
+}
+
+func TestFormats(t *testing.T) {
+	t.Run("fields", func(t *testing.T) {
+		document := NewSchema().GetStruct(formatDocument{})
+		id := document.EnsureFieldByName("id")
+		if id.NameFor("bson") != "_id" || !id.OmitEmptyFor("bson") || id.OmitEmptyFor("db") || id.NameFor("yaml") != "ID" {
+			t.Error("expected bson, db, and yaml metadata of id field")
+		}
+		title := document.EnsureFieldByName("title")
+		if title.NameFor("xml") != "Title" || len(title.Format("xml").Options) != 1 || title.Format("xml").Options[0] != "attr" {
+			t.Error("expected xml name and options of title field")
+		}
+		internal := document.EnsureFieldByName("internal")
+		if !internal.ExcludedFor("yaml") || !internal.ExcludedFor("form") || internal.ExcludedFor("json") || internal.ExcludedFor("msgpack") {
+			t.Error("expected internal field to be excluded from yaml and form only")
+		}
+		if internal.NameFor("toml") != "Internal" || internal.NameFor("json") != "internal" {
+			t.Error("expected names of untagged formats to be derived")
+		}
+	})
+
+	t.Run("naming strategy", func(t *testing.T) {
+		count := NewSchema().SetNamingStrategy(SnakeCase).GetStruct(formatDocument{}).EnsureFieldByName("Count")
+		if count.JSONName != "count" || count.NameFor("yaml") != "Count" || count.NameFor("toml") != "Count" {
+			t.Error("expected untagged formats other than JSON to have the field name regardless of the naming strategy")
+		}
+	})
+
+	t.Run("name tag", func(t *testing.T) {
+		s := NewSchema().SetNameTag("yaml")
+		document := s.GetStruct(formatDocument{})
+		if s.NameTag() != "yaml" || document.FieldByName("name") == nil || document.FieldByName("title") != nil || document.FieldByName("region") == nil {
+			t.Error("expected yaml names to be matched")
+		}
+		s.mu.Lock()
+		name := document.FieldByName("name")
+		s.mu.Unlock()
+		if name == nil {
+			t.Error("expected fields to be found by name while the schema is locked")
+		}
+		for _, field := range document.VisibleFields() {
+			if field.Name == "Internal" {
+				t.Error("expected field excluded from yaml not to be visible")
+			}
+		}
+		v, err := s.ConvertInterfaceValue(map[string]interface{}{"name": "Report", "region": "eu", "ID": "1"}, formatDocument{})
+		if err != nil {
+			t.Fatal("failed converting value: " + err.Error())
+		}
+		if d := v.(formatDocument); d.Title != "Report" || d.Region != "eu" || d.ID != "1" {
+			t.Error("expected map keys to be matched to yaml names")
+		}
+		data, err := s.MarshalValue(formatDocument{Title: "Report"})
+		if err != nil || string(data) != `{"Region":"","id":"","title":"Report","internal":"","Count":0}` {
+			t.Error("expected JSON names when marshaling but received " + string(data))
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package typemeta
+
+import (
+	"reflect"
+)
+
+// Func is type meta for a function
+type Func struct {
+	In       []TypeMeta // Type meta of the parameters
+	Out      []TypeMeta // Type meta of the results
+	Variadic bool       // Whether the last parameter is variadic, in which case its type meta is for a slice
+
+	typ         reflect.Type
+	annotations *annotationSet
+	funcName    string
+}
+
+// FuncName returns the name of the function the type meta was retrieved from using `GetFunc`, or the empty string if it was retrieved from a type.
+func (s *Func) FuncName() string {
+	return s.funcName
+}
+
+// ReturnsError returns whether the last result of the function is an error
+func (s *Func) ReturnsError() bool {
+	return len(s.Out) > 0 && s.Out[len(s.Out)-1].Type() == errorType
+}
+
+// Results returns the type meta of the results, excluding the last result if it is an error
+func (s *Func) Results() []TypeMeta {
+	if s.ReturnsError() {
+		return s.Out[:len(s.Out)-1]
+	}
+	return s.Out
+}
+
+// Type returns the type of the function
+func (s *Func) Type() reflect.Type {
+	return s.typ
+}
+
+// Kind returns `reflect.Func` for functions
+func (s *Func) Kind() reflect.Kind {
+	return reflect.Func
+}
+
+// Primitive returns false for functions
+func (s *Func) Primitive() bool {
+	return false
+}
+
+// JSONNonNull returns false for functions
+func (s *Func) JSONNonNull() bool {
+	return false
+}
+
+// Name returns the type meta's explicitly set name or the type's name within its package for a defined type. For other (non-defined) types it returns the empty string.
+func (s *Func) Name() string {
+	return s.typ.Name()
+}
+
+// Copy returns a copy of the func type meta
+func (s *Func) Copy() *Func {
+	ns := copyAnnotated(s)
+	ns.annotations = ns.annotations.clone()
+	return &ns
+}
+
+func (s *Func) String() string {
+	return s.Type().String()
+}
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+func (s *Func) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -0,0 +1,33 @@
+package typemeta
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFunc(t *testing.T) {
+	type Args struct {
+		ID string
+	}
+	resolve := func(args Args, tags ...string) (*Args, error) { return nil, nil }
+	fn := GetFunc(resolve)
+	if fn.Primitive() || fn.JSONNonNull() {
+		t.Error("func should be neither primitive nor JSON non-null")
+	}
+	if len(fn.In) != 2 || fn.In[0] != Get(Args{}) || fn.In[1].Kind() != reflect.Slice {
+		t.Error("func does not have the correct parameters")
+	}
+	if !fn.Variadic {
+		t.Error("func should be variadic")
+	}
+	if !fn.ReturnsError() || len(fn.Results()) != 1 || fn.Results()[0] != Get(&Args{}) {
+		t.Error("func does not have the correct results")
+	}
+	if !strings.HasPrefix(fn.FuncName(), "go-typemeta.TestFunc") {
+		t.Error("func does not have the correct func name, received " + fn.FuncName())
+	}
+	if Get(resolve).(*Func).FuncName() != "" {
+		t.Error("func type meta of schema should not have a func name")
+	}
+}
@@ -0,0 +1,80 @@
+package typemeta
+
+import (
+	"errors"
+	"reflect"
+)
+
+// MetaFor returns type meta of the type parameter from the default schema
+func MetaFor[T any]() TypeMeta {
+	return MetaIn[T](DefaultSchema)
+}
+
+// MetaIn returns type meta of the type parameter from the specified schema
+func MetaIn[T any](s *Schema) TypeMeta {
+	return s.get(typeFor[T](), false)
+}
+
+// StructFor returns struct type meta of the type parameter from the default schema. It panics if the type is not a struct.
+func StructFor[T any]() *Struct {
+	return StructIn[T](DefaultSchema)
+}
+
+// StructIn returns struct type meta of the type parameter from the specified schema. It panics if the type is not a struct.
+func StructIn[T any](s *Schema) *Struct {
+	return s.GetStruct(typeFor[T]())
+}
+
+// Convert converts a value to the type parameter using the default schema and returns a detailed error if it fails
+func Convert[T any](v interface{}) (T, error) {
+	return ConvertIn[T](DefaultSchema, v)
+}
+
+// ConvertIn converts a value to the type parameter using the specified schema and returns a detailed error if it fails
+func ConvertIn[T any](s *Schema, v interface{}) (T, error) {
+	if tv, ok := v.(T); ok && reflect.TypeOf(v) == typeFor[T]() {
+		return tv, nil
+	}
+	rv, ok := v.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(v)
+	}
+	cv, err := s.ConvertValue(rv, typeFor[T]())
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return valueAs[T](cv)
+}
+
+// Unmarshal unmarshals data to the type parameter using the default schema, sets default values, and returns an error if unsuccessful
+func Unmarshal[T any](data []byte) (T, error) {
+	return UnmarshalIn[T](DefaultSchema, data)
+}
+
+// UnmarshalIn unmarshals data to the type parameter using the specified schema, sets default values, and returns an error if unsuccessful
+func UnmarshalIn[T any](s *Schema, data []byte) (T, error) {
+	rv, err := unmarshalValue(&Converter{Schema: s}, MetaIn[T](s), data)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return valueAs[T](rv)
+}
+
+func typeFor[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+func valueAs[T any](rv reflect.Value) (T, error) {
+	if !rv.IsValid() || (rv.Kind() == reflect.Interface && rv.IsNil()) {
+		var zero T
+		return zero, nil
+	}
+	v, ok := rv.Interface().(T)
+	if !ok {
+		var zero T
+		return zero, errors.New(rv.Type().String() + " not assignable to " + typeFor[T]().String())
+	}
+	return v, nil
+}
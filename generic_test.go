@@ -0,0 +1,50 @@
+package typemeta
+
+import (
+	"testing"
+)
+
+type genericPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+func TestGeneric(t *testing.T) {
+	type StructA struct {
+		Name   string `json:"name"`
+		Active bool   `json:"active"`
+	}
+	if StructFor[StructA]() != GetStruct(StructA{}) {
+		t.Error("StructFor should return the struct type meta of the default schema")
+	}
+	if MetaFor[error]().Kind() != Get(errorType).Kind() {
+		t.Error("MetaFor should support interface types")
+	}
+	s := NewSchema()
+	if StructIn[StructA](s) == StructFor[StructA]() {
+		t.Error("StructIn should return the struct type meta of the specified schema")
+	}
+
+	a, err := Convert[StructA](map[string]interface{}{"name": "Test", "active": "true"})
+	if err != nil {
+		t.Fatal("failed converting: " + err.Error())
+	}
+	if a.Name != "Test" || !a.Active {
+		t.Error("converted value does not have the correct fields")
+	}
+	if n, err := Convert[int]("100"); err != nil || n != 100 {
+		t.Error("failed converting to int")
+	}
+
+	page, err := Unmarshal[genericPage[StructA]]([]byte(`{"items": [{"name": "Test"}], "total": 1}`))
+	if err != nil {
+		t.Fatal("failed unmarshaling: " + err.Error())
+	}
+	if len(page.Items) != 1 || page.Items[0].Name != "Test" || page.Total != 1 {
+		t.Error("unmarshaled value does not have the correct fields")
+	}
+	pageMeta := MetaIn[genericPage[StructA]](s)
+	if names, _ := s.Names(); names[pageMeta] != "genericPageStructA" {
+		t.Error("generic type should have a sanitized name but received " + names[pageMeta])
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/ludvigalden/go-typemeta
 
-go 1.16
+go 1.18
 
 require github.com/fatih/structtag v1.2.0
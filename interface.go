@@ -6,7 +6,8 @@ import (
 
 // Interface is type meta for an interface
 type Interface struct {
-	typ reflect.Type
+	typ         reflect.Type
+	annotations *annotationSet
 }
 
 // Primitive returns true for interfaces
@@ -36,9 +37,14 @@ func (s *Interface) Name() string {
 	}
 	return s.typ.Name()
 }
+
 func (s *Interface) String() string {
 	if s.typ == nil {
 		return "interface{}"
 	}
 	return s.typ.String()
 }
+
+func (s *Interface) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -0,0 +1,69 @@
+package typemeta
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type jsonStringOrder struct {
+	ID     int64    `json:"id,string"`
+	Paid   bool     `json:"paid,string"`
+	Total  *float64 `json:"total,string"`
+	Code   string   `json:"code,string"`
+	Items  []int    `json:"items,string"`
+	Amount int      `json:"amount"`
+}
+
+func TestJSONString(t *testing.T) {
+	s := NewSchema()
+	order := s.GetStruct(jsonStringOrder{})
+
+	t.Run("fields", func(t *testing.T) {
+		if !order.EnsureFieldByName("id").JSONString || !order.EnsureFieldByName("total").JSONString || order.EnsureFieldByName("items").JSONString || order.EnsureFieldByName("amount").JSONString {
+			t.Error("expected the ,string option to apply to strings, numbers, and booleans only")
+		}
+	})
+
+	data := []byte(`{"id":"9007199254740993","paid":"true","total":"12.5","code":"\"A1\"","items":[1],"amount":3}`)
+
+	t.Run("decode", func(t *testing.T) {
+		var expected jsonStringOrder
+		if err := json.Unmarshal(data, &expected); err != nil {
+			t.Fatal(err)
+		}
+		v, err := s.UnmarshalValue(jsonStringOrder{}, data)
+		if err != nil {
+			t.Fatal("failed unmarshaling value: " + err.Error())
+		}
+		if !reflect.DeepEqual(v, expected) {
+			t.Error("expected unmarshaled value to equal that of encoding/json")
+		}
+		if _, err := s.UnmarshalValue(jsonStringOrder{}, []byte(`{"id":5}`)); err == nil {
+			t.Error("expected unquoted value to be rejected")
+		}
+		if _, err := s.ConvertInterfaceValue(map[string]interface{}{"paid": "yes"}, jsonStringOrder{}); err == nil {
+			t.Error("expected invalid quoted value to be rejected")
+		}
+		if v, err := s.ConvertInterfaceValue(map[string]interface{}{"total": nil}, jsonStringOrder{}); err != nil || v.(jsonStringOrder).Total != nil {
+			t.Error("expected null to be accepted")
+		}
+		v, err = s.ConvertInterfaceValue(map[string]interface{}{"id": 5, "paid": true, "total": 1.5}, jsonStringOrder{})
+		if err != nil || v.(jsonStringOrder).ID != 5 || !v.(jsonStringOrder).Paid || *v.(jsonStringOrder).Total != 1.5 {
+			t.Error("expected typed values to be converted regardless of the ,string option")
+		}
+		if v, err := s.ConvertInterfaceValue(map[string]int64{"id": 5}, jsonStringOrder{}); err != nil || v.(jsonStringOrder).ID != 5 {
+			t.Error("expected values of typed maps to be converted regardless of the ,string option")
+		}
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		total := 12.5
+		v := jsonStringOrder{ID: 9007199254740993, Paid: true, Total: &total, Code: "A1", Items: []int{1}, Amount: 3}
+		expected, _ := json.Marshal(v)
+		data, err := s.MarshalValue(v)
+		if err != nil || string(data) != string(expected) {
+			t.Error("expected " + string(expected) + " but received " + string(data))
+		}
+	})
+}
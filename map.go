@@ -9,7 +9,8 @@ type Map struct {
 	Key  TypeMeta
 	Elem TypeMeta
 
-	typ reflect.Type
+	typ         reflect.Type
+	annotations *annotationSet
 }
 
 var _ TypeMeta = &Map{}
@@ -42,3 +43,7 @@ func (s *Map) Name() string {
 func (s *Map) String() string {
 	return "map[" + s.Key.String() + "]" + s.Elem.String()
 }
+
+func (s *Map) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -0,0 +1,336 @@
+package typemeta
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+// MarshalValue marshals a value to JSON like `json.Marshal`, but based on the type metas of the default schema. See `Schema.MarshalValue`.
+func MarshalValue(v interface{}) ([]byte, error) {
+	return DefaultSchema.MarshalValue(v)
+}
+
+// MarshalValue marshals a value to JSON like `json.Marshal`, but based on the type metas of the schema, meaning that field metadata
+// which is not in the struct tags, e.g. JSON names set using `EditField`, is respected. Write-only fields are never emitted.
+// Custom scalars are marshaled using their Serialize functions (see `RegisterScalar`), types implementing `json.Marshaler` or
+// `encoding.TextMarshaler` are marshaled using those methods, primitive types implementing `driver.Valuer` but no marshaler are
+// marshaled as their driver values, and an error is returned for cyclic values.
+func (s *Schema) MarshalValue(v interface{}) ([]byte, error) {
+	rv, ok := v.(reflect.Value)
+	if !ok {
+		rv = reflect.ValueOf(v)
+	}
+	e := encoder{schema: s, onPath: map[visit]bool{}}
+	if !rv.IsValid() {
+		return []byte("null"), nil
+	}
+	if err := e.encode(rv, s.Get(rv.Type())); err != nil {
+		return nil, err
+	}
+	return e.buf.Bytes(), nil
+}
+
+// visit identifies a pointer, map, or slice value being encoded, for detecting cycles
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+	len int
+}
+
+// encoder encodes values as JSON based on their type metas
+type encoder struct {
+	schema *Schema
+	buf    bytes.Buffer
+	onPath map[visit]bool
+}
+
+func (e *encoder) encode(value reflect.Value, tm TypeMeta) error {
+	if !value.IsValid() {
+		e.buf.WriteString("null")
+		return nil
+	}
+	if scalar := scalarOf(tm); scalar != nil && scalar.Serialize != nil && value.CanInterface() {
+		serialized, err := serializeScalar(value, tm.(*Primitive))
+		if err != nil {
+			return err
+		}
+		if !serialized.IsValid() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(serialized, e.schema.Get(serialized.Type()))
+	}
+	if driverValue, ok, err := driverValueOf(value, tm); ok {
+		// encoded like its driver value, which is scanned when unmarshaling
+		if err != nil {
+			return err
+		}
+		if driverValue == nil {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(reflect.ValueOf(driverValue), e.schema.Get(reflect.TypeOf(driverValue)))
+	}
+	if value.CanInterface() && implementsMarshaler(value.Type()) {
+		if value.Kind() == reflect.Ptr && value.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		data, err := json.Marshal(value.Interface())
+		if err != nil {
+			return err
+		}
+		e.buf.Write(data)
+		return nil
+	}
+	switch t := tm.(type) {
+	case *Ptr:
+		if value.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encodeVisit(visit{value.Pointer(), value.Type(), 0}, tm, func() error {
+			return e.encode(value.Elem(), t.Elem)
+		})
+	case *Interface:
+		if value.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encode(value.Elem(), e.schema.Get(value.Elem().Type()))
+	case *Struct:
+		return e.encodeStruct(value, t)
+	case *Slice:
+		if value.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		if value.Type().Elem().Kind() == reflect.Uint8 && !implementsMarshaler(value.Type().Elem()) {
+			// encoded as a base64 string
+			data, _ := json.Marshal(value.Bytes())
+			e.buf.Write(data)
+			return nil
+		}
+		return e.encodeVisit(visit{value.Pointer(), value.Type(), value.Len()}, tm, func() error {
+			return e.encodeElems(value, t.Elem)
+		})
+	case *Array:
+		return e.encodeElems(value, t.Elem)
+	case *Map:
+		if value.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		return e.encodeVisit(visit{value.Pointer(), value.Type(), 0}, tm, func() error {
+			return e.encodeMap(value, t)
+		})
+	case *Primitive:
+		return e.encodePrimitive(value, tm)
+	default:
+		return errors.New("cannot marshal unsupported type " + tm.String())
+	}
+}
+
+// encodeVisit encodes a pointer, map, or slice value unless it is already being encoded, i.e. unless the value is cyclic
+func (e *encoder) encodeVisit(v visit, tm TypeMeta, encode func() error) error {
+	if e.onPath[v] {
+		return errors.New("cannot marshal cyclic value of type " + tm.String())
+	}
+	e.onPath[v] = true
+	err := encode()
+	delete(e.onPath, v)
+	return err
+}
+
+func (e *encoder) encodeStruct(value reflect.Value, strct *Struct) error {
+	e.buf.WriteByte('{')
+	first := true
+	for _, field := range strct.visibleFieldsFor("json") {
+		if field.Access == WriteOnly {
+			continue
+		}
+		fieldValue, err := value.FieldByIndexErr(field.IndexPath)
+		if err != nil {
+			// promoted through a nil embedded pointer
+			continue
+		}
+		if field.JSONOmitEmpty && isEmptyValue(fieldValue) {
+			continue
+		}
+		if !first {
+			e.buf.WriteByte(',')
+		}
+		first = false
+		e.encodeString(field.JSONName)
+		e.buf.WriteByte(':')
+		if field.JSONString {
+			if err := e.encodeQuoted(fieldValue, field.TypeMeta); err != nil {
+				return err
+			}
+		} else if err := e.encode(fieldValue, field.TypeMeta); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+// encodeQuoted encodes the value of a field with the `,string` JSON option as a JSON string containing the encoded value
+func (e *encoder) encodeQuoted(value reflect.Value, tm TypeMeta) error {
+	if ptr, ok := tm.(*Ptr); ok {
+		if value.IsNil() {
+			e.buf.WriteString("null")
+			return nil
+		}
+		value, tm = value.Elem(), ptr.Elem
+	}
+	if value.CanInterface() && implementsMarshaler(value.Type()) {
+		// like encoding/json, marshalers are not quoted
+		return e.encode(value, tm)
+	}
+	start := e.buf.Len()
+	if err := e.encode(value, tm); err != nil {
+		return err
+	}
+	encoded := e.buf.String()[start:]
+	e.buf.Truncate(start)
+	e.encodeString(encoded)
+	return nil
+}
+
+func (e *encoder) encodeElems(value reflect.Value, elem TypeMeta) error {
+	e.buf.WriteByte('[')
+	for i := 0; i < value.Len(); i++ {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		if err := e.encode(value.Index(i), elem); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte(']')
+	return nil
+}
+
+func (e *encoder) encodeMap(value reflect.Value, mp *Map) error {
+	type entry struct {
+		key   string
+		value reflect.Value
+	}
+	entries := make([]entry, 0, value.Len())
+	mapIter := value.MapRange()
+	for mapIter.Next() {
+		key, err := mapKeyString(mapIter.Key())
+		if err != nil {
+			return err
+		}
+		entries = append(entries, entry{key, mapIter.Value()})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+	e.buf.WriteByte('{')
+	for i, entry := range entries {
+		if i > 0 {
+			e.buf.WriteByte(',')
+		}
+		e.encodeString(entry.key)
+		e.buf.WriteByte(':')
+		if err := e.encode(entry.value, mp.Elem); err != nil {
+			return err
+		}
+	}
+	e.buf.WriteByte('}')
+	return nil
+}
+
+func (e *encoder) encodePrimitive(value reflect.Value, tm TypeMeta) error {
+	switch value.Kind() {
+	case reflect.Bool:
+		e.buf.WriteString(strconv.FormatBool(value.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		e.buf.WriteString(strconv.FormatInt(value.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		e.buf.WriteString(strconv.FormatUint(value.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		var data []byte
+		var err error
+		if value.Kind() == reflect.Float32 {
+			data, err = json.Marshal(float32(value.Float()))
+		} else {
+			data, err = json.Marshal(value.Float())
+		}
+		if err != nil {
+			return err
+		}
+		e.buf.Write(data)
+	case reflect.String:
+		e.encodeString(value.String())
+	case reflect.Struct, reflect.Array, reflect.Slice:
+		// primitive because it is scanned (see `sql.Scanner`), but encoded like by `encoding/json`
+		data, err := json.Marshal(value.Interface())
+		if err != nil {
+			return err
+		}
+		e.buf.Write(data)
+	default:
+		return errors.New("cannot marshal unsupported type " + tm.String())
+	}
+	return nil
+}
+
+func (e *encoder) encodeString(str string) {
+	data, _ := json.Marshal(str)
+	e.buf.Write(data)
+}
+
+// mapKeyString returns the JSON object key of a map key like `json.Marshal`
+func mapKeyString(key reflect.Value) (string, error) {
+	if key.Kind() == reflect.String {
+		return key.String(), nil
+	}
+	if key.Type().Implements(textMarshalerType) && key.CanInterface() {
+		if key.Kind() == reflect.Ptr && key.IsNil() {
+			return "", nil
+		}
+		text, err := key.Interface().(encoding.TextMarshaler).MarshalText()
+		return string(text), err
+	}
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), nil
+	}
+	return "", errors.New("cannot marshal map key of unsupported type " + key.Type().String())
+}
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+func implementsMarshaler(typ reflect.Type) bool {
+	return typ.Implements(primitiveType) || typ.Implements(textMarshalerType)
+}
+
+// isEmptyValue returns whether a value is empty as defined by the `omitempty` option of `encoding/json`
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return value.IsNil()
+	}
+	return false
+}
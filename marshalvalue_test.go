@@ -0,0 +1,56 @@
+package typemeta
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type marshalNode struct {
+	Name     string            `json:"name"`
+	Next     *marshalNode      `json:"next,omitempty"`
+	Children []marshalNode     `json:"children"`
+	Labels   map[int]string    `json:"labels,omitempty"`
+	Meta     interface{}       `json:"meta"`
+	Data     []byte            `json:"data"`
+	Weights  map[string]uint16 `json:"weights"`
+}
+
+func TestMarshalValue(t *testing.T) {
+	s := NewSchema()
+
+	t.Run("json", func(t *testing.T) {
+		node := marshalNode{
+			Name:     "root",
+			Next:     &marshalNode{Name: "next", Meta: map[string]interface{}{"a": []int{1, 2}}},
+			Children: []marshalNode{{Name: "child", Labels: map[int]string{2: "b", 1: "a"}}},
+			Meta:     3.5,
+			Data:     []byte("data"),
+			Weights:  map[string]uint16{"x": 1},
+		}
+		expected, _ := json.Marshal(node)
+		data, err := s.MarshalValue(node)
+		if err != nil {
+			t.Fatal("failed marshaling value: " + err.Error())
+		}
+		if string(data) != string(expected) {
+			t.Error("expected " + string(expected) + " but received " + string(data))
+		}
+	})
+
+	t.Run("edited", func(t *testing.T) {
+		s.GetStruct(marshalNode{}).EditField("Name").JSONName("title")
+		data, err := s.MarshalValue(reflect.ValueOf(&marshalNode{Name: "root"}))
+		if err != nil || string(data) != `{"title":"root","children":null,"meta":null,"data":null,"weights":null}` {
+			t.Error("expected edited JSON name but received " + string(data))
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		node := &marshalNode{Name: "root"}
+		node.Next = node
+		if _, err := s.MarshalValue(node); err == nil {
+			t.Error("expected cyclic value to be rejected")
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package typemeta
+
+import (
+	"reflect"
+)
+
+// Method is meta for a method of a type
+type Method struct {
+	Name            string // Name of the method
+	PointerReceiver bool   // Whether the method has a pointer receiver, meaning it is not in the method set of the non-pointer type
+	Func            *Func  // Signature of the method, excluding the receiver
+}
+
+// Getter returns whether the method is a getter, i.e. it takes no arguments and returns a single value, optionally followed by an error.
+// For instance, `FullName() string` and `Total() (int, error)` are getters.
+func (m Method) Getter() bool {
+	return len(m.Func.In) == 0 && len(m.Func.Results()) == 1
+}
+
+func (m Method) String() string {
+	return m.Name + m.Func.String()[len("func"):]
+}
+
+// Methods is a list of methods, ordered by name
+type Methods []Method
+
+// ByName returns the method with the specified name. If no matching method is found, nil is returned.
+func (m Methods) ByName(name string) *Method {
+	for _, method := range m {
+		if method.Name == name {
+			return &method
+		}
+	}
+	return nil
+}
+
+// Getters returns the methods that are getters. See `Method.Getter`.
+func (m Methods) Getters() Methods {
+	getters := Methods{}
+	for _, method := range m {
+		if method.Getter() {
+			getters = append(getters, method)
+		}
+	}
+	return getters
+}
+
+// ValueMethods returns the methods with a value receiver, i.e. the method set of the non-pointer type
+func (m Methods) ValueMethods() Methods {
+	valueMethods := Methods{}
+	for _, method := range m {
+		if !method.PointerReceiver {
+			valueMethods = append(valueMethods, method)
+		}
+	}
+	return valueMethods
+}
+
+// Implements returns whether the method set of the non-pointer type implements the specified interface type, e.g.
+// `reflect.TypeOf((*json.Marshaler)(nil)).Elem()`, like `reflect.Type.Implements`. Methods with a pointer receiver are ignored.
+func (m Methods) Implements(iface reflect.Type) bool {
+	return m.ValueMethods().PointerImplements(iface)
+}
+
+// PointerImplements returns whether the method set of the pointer type implements the specified interface type, i.e. including methods with a pointer receiver
+func (m Methods) PointerImplements(iface reflect.Type) bool {
+	for i := 0; i < iface.NumMethod(); i++ {
+		ifaceMethod := iface.Method(i)
+		method := m.ByName(ifaceMethod.Name)
+		if method == nil || method.Func.Type() != ifaceMethod.Type {
+			return false
+		}
+	}
+	return true
+}
+
+// methodsOf returns the methods of a (pointer to a) type with type meta of their signatures retrieved from the specified schema
+func methodsOf(s *Schema, typ reflect.Type) Methods {
+	s = schemaOf(s)
+	ptrType := typ
+	if typ.Kind() != reflect.Ptr {
+		ptrType = reflect.PtrTo(typ)
+	}
+	methods := Methods{}
+	for i := 0; i < ptrType.NumMethod(); i++ {
+		rm := ptrType.Method(i)
+		in := make([]reflect.Type, rm.Type.NumIn()-1)
+		for j := range in {
+			in[j] = rm.Type.In(j + 1)
+		}
+		out := make([]reflect.Type, rm.Type.NumOut())
+		for j := range out {
+			out[j] = rm.Type.Out(j)
+		}
+		_, valueMethod := ptrType.Elem().MethodByName(rm.Name)
+		methods = append(methods, Method{
+			Name:            rm.Name,
+			PointerReceiver: !valueMethod,
+			Func:            s.Get(reflect.FuncOf(in, out, rm.Type.IsVariadic())).(*Func),
+		})
+	}
+	return methods
+}
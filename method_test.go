@@ -0,0 +1,53 @@
+package typemeta
+
+import (
+	"encoding"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type methodsPerson struct {
+	FirstName string
+	LastName  string
+}
+
+func (p methodsPerson) FullName() string {
+	return p.FirstName + " " + p.LastName
+}
+
+func (p *methodsPerson) SetName(firstName, lastName string) {
+	p.FirstName, p.LastName = firstName, lastName
+}
+
+func TestMethods(t *testing.T) {
+	methods := GetStruct(methodsPerson{}).Methods()
+	if len(methods) != 2 {
+		t.Fatal("expected 2 methods but received " + strconv.Itoa(len(methods)))
+	}
+	fullName := methods.ByName("FullName")
+	if fullName == nil || fullName.PointerReceiver || !fullName.Getter() || fullName.String() != "FullName() string" {
+		t.Error("FullName should be a getter with a value receiver")
+	}
+	setName := methods.ByName("SetName")
+	if setName == nil || !setName.PointerReceiver || setName.Getter() || len(setName.Func.In) != 2 {
+		t.Error("SetName should be a setter with a pointer receiver")
+	}
+	if getters := methods.Getters(); len(getters) != 1 || getters[0].Name != "FullName" {
+		t.Error("expected FullName to be the only getter")
+	}
+	if len(Get(&methodsPerson{}).(*Ptr).Methods()) != 2 {
+		t.Error("pointer should have the same methods as the struct")
+	}
+
+	timeMethods := GetPrimitive(time.Time{}).Methods()
+	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshaler := reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	if !timeMethods.Implements(textMarshaler) || !timeMethods.PointerImplements(textMarshaler) {
+		t.Error("time.Time should implement encoding.TextMarshaler")
+	}
+	if timeMethods.Implements(textUnmarshaler) || !timeMethods.PointerImplements(textUnmarshaler) {
+		t.Error("only *time.Time should implement encoding.TextUnmarshaler")
+	}
+}
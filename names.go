@@ -0,0 +1,191 @@
+package typemeta
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// TypeNamePolicy returns an alternative name for a type meta whose name collides with the name of another type meta of a schema.
+// The passed name is the (sanitized) name that collided.
+type TypeNamePolicy func(t TypeMeta, name string) string
+
+// PackagePrefixTypeNames is a type name policy prefixing the name with the last element of the package path of the type,
+// e.g. "AuthUser" for a "User" type in package "example.com/auth".
+func PackagePrefixTypeNames(t TypeMeta, name string) string {
+	if t.Type() == nil {
+		return name
+	}
+	pkgPath := t.Type().PkgPath()
+	pkgName := pkgPath[strings.LastIndex(pkgPath, "/")+1:]
+	return upperFirst(sanitizeTypeName(pkgName)) + name
+}
+
+// NameCollisionError is returned when multiple type metas of a schema have the same name
+type NameCollisionError struct {
+	Name  string
+	Types []TypeMeta
+}
+
+func (e *NameCollisionError) Error() string {
+	strs := make([]string, len(e.Types))
+	for i, t := range e.Types {
+		strs[i] = QualifiedName(t)
+	}
+	return "name \"" + e.Name + "\" is used by multiple types: " + strings.Join(strs, ", ")
+}
+
+// SetTypeNamePolicy sets the policy used by `Names` to resolve name collisions
+func (s *Schema) SetTypeNamePolicy(policy TypeNamePolicy) *Schema {
+	s.mu.Lock()
+	s.typeNamePolicy = policy
+	s.mu.Unlock()
+	s.fieldsChanged()
+	return s
+}
+
+// Names returns a unique name for each named type meta of the schema. Explicitly set names (e.g. `Struct.SetName`) are used as is, while
+// names derived from types are sanitized, e.g. "PageUser" for `Page[main.User]`. When a derived name collides with another name, the type
+// name policy of the schema is used to resolve it (see `SetTypeNamePolicy`). Remaining collisions are returned as `*NameCollisionError`s,
+// in which case the returned names are not unique.
+func (s *Schema) Names() (map[TypeMeta]string, error) {
+	s.mu.Lock()
+	policy := s.typeNamePolicy
+	s.mu.Unlock()
+
+	names := map[TypeMeta]string{}
+	explicit := map[TypeMeta]bool{}
+	byName := map[string][]TypeMeta{}
+	for _, t := range s.namedTypes() {
+		name := explicitName(t)
+		if name != "" {
+			explicit[t] = true
+		} else {
+			name = sanitizeTypeName(t.Name())
+		}
+		names[t] = name
+		byName[name] = append(byName[name], t)
+	}
+
+	if policy != nil {
+		for name, types := range byName {
+			if len(types) < 2 {
+				continue
+			}
+			for _, t := range types {
+				if !explicit[t] {
+					names[t] = policy(t, name)
+				}
+			}
+		}
+		byName = map[string][]TypeMeta{}
+		for _, t := range s.namedTypes() {
+			byName[names[t]] = append(byName[names[t]], t)
+		}
+	}
+
+	var errs Errors
+	collidingNames := []string{}
+	for name, types := range byName {
+		if len(types) > 1 {
+			collidingNames = append(collidingNames, name)
+		}
+	}
+	sort.Strings(collidingNames)
+	for _, name := range collidingNames {
+		errs = append(errs, &NameCollisionError{name, byName[name]})
+	}
+	return names, errorsOrNil(errs)
+}
+
+// namedTypes returns the named type metas of the schema, i.e. excluding predeclared and non-defined types, sorted by qualified name
+func (s *Schema) namedTypes() []TypeMeta {
+	s.mu.Lock()
+	types := []TypeMeta{}
+	for _, t := range s.types {
+		if isNamed(t) {
+			types = append(types, t)
+		}
+	}
+	s.mu.Unlock()
+	sortTypes(types)
+	return types
+}
+
+// isNamed returns whether the type meta is named, i.e. it has an explicitly set name or it is for a defined type that is not predeclared
+func isNamed(t TypeMeta) bool {
+	if t.Type() == nil || t.Name() == "" {
+		return false
+	}
+	return t.Type().PkgPath() != "" || explicitName(t) != ""
+}
+
+// sortTypes sorts type metas by qualified name, and then by string representation
+func sortTypes(types []TypeMeta) {
+	sort.Slice(types, func(i, j int) bool {
+		if qi, qj := QualifiedName(types[i]), QualifiedName(types[j]); qi != qj {
+			return qi < qj
+		}
+		return types[i].String() < types[j].String()
+	})
+}
+
+// explicitName returns the explicitly set name of the type meta, or the empty string
+func explicitName(t TypeMeta) string {
+	switch t := t.(type) {
+	case *Struct:
+		return t.name
+	case *Primitive:
+		return t.name
+	default:
+		return ""
+	}
+}
+
+// QualifiedName returns the name of the type meta prefixed with the package path of the type, e.g. "example.com/auth.User".
+// For types without a package path it returns the same as `Name`.
+func QualifiedName(t TypeMeta) string {
+	name, typ := t.Name(), t.Type()
+	if name == "" || typ == nil || typ.PkgPath() == "" {
+		return name
+	}
+	return typ.PkgPath() + "." + name
+}
+
+var typeNameIdentifierRegexp = regexp.MustCompile(`[\w./-]+`)
+
+// matches the suffix the compiler adds to names of types declared in functions
+var localTypeSuffixRegexp = regexp.MustCompile(`·\d+`)
+
+// sanitizeTypeName returns the name as an identifier, e.g. "PageUser" for "Page[main.User]" and "PairStringTime" for "Pair[string,*time.Time]"
+func sanitizeTypeName(name string) string {
+	name = localTypeSuffixRegexp.ReplaceAllString(name, "")
+	var b strings.Builder
+	for i, part := range typeNameIdentifierRegexp.FindAllString(name, -1) {
+		part = part[strings.LastIndexAny(part, "./")+1:]
+		part = strings.Map(func(r rune) rune {
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, part)
+		if part == "" {
+			continue
+		}
+		if i > 0 {
+			part = upperFirst(part)
+		}
+		b.WriteString(part)
+	}
+	return b.String()
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	runes := []rune(s)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
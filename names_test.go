@@ -0,0 +1,116 @@
+package typemeta
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+type namesUser struct {
+	Name string
+}
+
+func TestNames(t *testing.T) {
+	s := NewSchema()
+	outer := s.GetStruct(namesUser{})
+	renamed := s.GetStruct(struct{ A string }{}).SetName("namesUser")
+	s.Get(map[string]int{})
+
+	if QualifiedName(outer) != "github.com/ludvigalden/go-typemeta.namesUser" {
+		t.Error("unexpected qualified name " + QualifiedName(outer))
+	}
+	if QualifiedName(s.Get("")) != "string" {
+		t.Error("predeclared types should not be qualified")
+	}
+	if QualifiedName(renamed) != "namesUser" {
+		t.Error("non-defined types should not be qualified")
+	}
+
+	names, err := s.Names()
+	var collision *NameCollisionError
+	if !errors.As(err, &collision) || collision.Name != "namesUser" || len(collision.Types) != 2 {
+		t.Fatal("expected a collision of namesUser")
+	}
+	if names[renamed] != "namesUser" {
+		t.Error("explicit name should be used as is")
+	}
+
+	s = NewSchema().SetTypeNamePolicy(PackagePrefixTypeNames)
+	outer = s.GetStruct(namesUser{})
+	renamed = s.GetStruct(struct{ A string }{}).SetName("namesUser")
+	names, err = s.Names()
+	if err != nil {
+		t.Fatal("expected no collisions but received " + err.Error())
+	}
+	if names[outer] != "GotypemetanamesUser" || names[renamed] != "namesUser" {
+		t.Error("expected derived name to be prefixed, received " + names[outer])
+	}
+	if _, ok := names[s.Get("")]; ok {
+		t.Error("predeclared types should not be named")
+	}
+}
+
+func TestSanitizeTypeName(t *testing.T) {
+	cd := map[string]string{
+		"User":                                  "User",
+		"Page[main.User]":                       "PageUser",
+		"Pair[string,map[string]*time.Time]":    "PairStringMapStringTime",
+		"Page[example.com/x/y.Page[main.User]]": "PagePageUser",
+	}
+	for name, expected := range cd {
+		if sanitized := sanitizeTypeName(name); sanitized != expected {
+			t.Error("expected " + name + " to be sanitized to " + expected + " but received " + sanitized)
+		}
+	}
+}
+
+func TestSchemaLookup(t *testing.T) {
+	type lookupOrder struct {
+		Items []depsItem
+	}
+	type lookupGroup struct {
+		Orders []lookupOrder
+	}
+	s := NewSchema()
+	s.Register(lookupOrder{}, namesUser{})
+	renamed := s.GetStruct(struct{ A string }{}).SetName("Renamed")
+
+	if order, ok := s.Lookup("lookupOrder"); !ok || order != s.Get(lookupOrder{}) {
+		t.Error("expected lookupOrder to be found by name")
+	}
+	if item, ok := s.Lookup("github.com/ludvigalden/go-typemeta.depsItem"); !ok || item != s.Get(depsItem{}) {
+		t.Error("expected depsItem to be found by qualified name")
+	}
+	if r, ok := s.Lookup("Renamed"); !ok || r != renamed {
+		t.Error("expected struct to be found by explicit name")
+	}
+	if _, ok := s.Lookup("string"); ok {
+		t.Error("predeclared types should not be found")
+	}
+
+	types := s.Types()
+	for i := 1; i < len(types); i++ {
+		if QualifiedName(types[i-1]) > QualifiedName(types[i]) {
+			t.Error("types should be sorted by qualified name")
+		}
+	}
+	if structs := s.TypesOfKind(reflect.Struct); len(structs) != 4 {
+		t.Error("expected 4 struct types but received " + fmt.Sprint(len(structs)))
+	}
+
+	renamed.SetName("Anonymous")
+	if _, ok := s.Lookup("Renamed"); ok {
+		t.Error("expected renamed struct not to be found by its previous name")
+	}
+	if r, ok := s.Lookup("Anonymous"); !ok || r != renamed {
+		t.Error("expected struct to be found by its new name")
+	}
+	if _, ok := s.Lookup("lookupGroup"); ok {
+		t.Error("expected unregistered type not to be found")
+	}
+	s.Get(lookupGroup{})
+	if g, ok := s.Lookup("lookupGroup"); !ok || g != s.Get(lookupGroup{}) {
+		t.Error("expected type added after a lookup to be found")
+	}
+}
@@ -0,0 +1,82 @@
+package typemeta
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy derives the JSON name of a struct field from its Go name, for fields without a JSON name in their `json` tag
+type NamingStrategy func(fieldName string) string
+
+// CamelCase is a naming strategy deriving camelCase names, keeping acronyms other than the first word upper case, e.g. "httpServerID" for `HTTPServerID`
+func CamelCase(fieldName string) string {
+	words := splitWords(fieldName)
+	if len(words) == 0 {
+		return fieldName
+	}
+	words[0] = strings.ToLower(words[0])
+	for i := 1; i < len(words); i++ {
+		words[i] = upperFirst(words[i])
+	}
+	return strings.Join(words, "")
+}
+
+// SnakeCase is a naming strategy deriving snake_case names, e.g. "http_server_id" for `HTTPServerID`
+func SnakeCase(fieldName string) string {
+	return strings.ToLower(strings.Join(splitWords(fieldName), "_"))
+}
+
+// KebabCase is a naming strategy deriving kebab-case names, e.g. "http-server-id" for `HTTPServerID`
+func KebabCase(fieldName string) string {
+	return strings.ToLower(strings.Join(splitWords(fieldName), "-"))
+}
+
+// SetNamingStrategy sets the naming strategy used to derive the JSON names of struct fields without a JSON name in their `json` tag,
+// e.g. `CamelCase`. By default, the Go field name is used, as it is for untagged fields in other formats (see `StructField.Format`).
+// The strategy applies to struct type metas built after it is set, so it should be set before the schema is used.
+func (s *Schema) SetNamingStrategy(strategy NamingStrategy) *Schema {
+	s.mu.Lock()
+	s.namingStrategy = strategy
+	s.mu.Unlock()
+	return s
+}
+
+// derivedJSONName returns the JSON name of a field without a JSON name in its `json` tag. The schema mutex must be locked.
+func (s *Schema) derivedJSONName(fieldName string) string {
+	if s.namingStrategy == nil {
+		return fieldName
+	}
+	return s.namingStrategy(fieldName)
+}
+
+// splitWords splits a Go identifier into words, e.g. "HTTP", "Server", and "ID" for `HTTPServerID`.
+// Underscores separate words, digits are part of the preceding word, and a plural "s" is part of the preceding acronym, e.g. "IDs" for `UserIDs`.
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		split := i == len(runes) || runes[i] == '_'
+		if !split && i > start && unicode.IsUpper(runes[i]) {
+			prev := runes[i-1]
+			// a lower case letter or digit followed by an upper case letter, or the last upper case letter of an acronym followed by a lower case letter
+			split = unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && i+1 < len(runes) && unicode.IsLower(runes[i+1]) && !pluralSuffix(runes, i+1))
+		}
+		if !split {
+			continue
+		}
+		if i > start {
+			words = append(words, string(runes[start:i]))
+		}
+		start = i
+		if i < len(runes) && runes[i] == '_' {
+			start = i + 1
+		}
+	}
+	return words
+}
+
+// pluralSuffix returns whether the rune at index i is a lone lower case "s" ending a word, e.g. in `URLs` or `IDsByName`
+func pluralSuffix(runes []rune, i int) bool {
+	return runes[i] == 's' && (i+1 == len(runes) || !unicode.IsLower(runes[i+1]))
+}
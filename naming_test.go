@@ -0,0 +1,76 @@
+package typemeta
+
+import (
+	"testing"
+)
+
+type namingEvent struct {
+	HTTPServerID string
+	UserName     string `json:",omitempty"`
+	Tagged       string `json:"TAGGED"`
+	OAuth2Token  string
+	Count        int
+}
+
+func TestNamingStrategy(t *testing.T) {
+	t.Run("strategies", func(t *testing.T) {
+		cases := []struct {
+			name, camel, snake, kebab string
+		}{
+			{"HTTPServerID", "httpServerID", "http_server_id", "http-server-id"},
+			{"UserName", "userName", "user_name", "user-name"},
+			{"ID", "id", "id", "id"},
+			{"OAuth2Token", "oAuth2Token", "o_auth2_token", "o-auth2-token"},
+			{"Field_name", "fieldName", "field_name", "field-name"},
+			{"X", "x", "x", "x"},
+			{"UserIDs", "userIDs", "user_ids", "user-ids"},
+			{"URLs", "urls", "urls", "urls"},
+			{"HTTPServer", "httpServer", "http_server", "http-server"},
+			{"APIsByName", "apisByName", "apis_by_name", "apis-by-name"},
+		}
+		for _, c := range cases {
+			if camel := CamelCase(c.name); camel != c.camel {
+				t.Error("expected camel case \"" + c.camel + "\" of \"" + c.name + "\" but received \"" + camel + "\"")
+			}
+			if snake := SnakeCase(c.name); snake != c.snake {
+				t.Error("expected snake case \"" + c.snake + "\" of \"" + c.name + "\" but received \"" + snake + "\"")
+			}
+			if kebab := KebabCase(c.name); kebab != c.kebab {
+				t.Error("expected kebab case \"" + c.kebab + "\" of \"" + c.name + "\" but received \"" + kebab + "\"")
+			}
+		}
+	})
+
+	t.Run("schema", func(t *testing.T) {
+		s := NewSchema().SetNamingStrategy(SnakeCase)
+		event := s.GetStruct(namingEvent{})
+		expected := map[string]string{"HTTPServerID": "http_server_id", "UserName": "user_name", "Tagged": "TAGGED", "Count": "count"}
+		for name, jsonName := range expected {
+			if field := event.EnsureFieldByName(name); field.JSONName != jsonName {
+				t.Error("expected JSON name \"" + jsonName + "\" of field \"" + name + "\" but received \"" + field.JSONName + "\"")
+			}
+		}
+		data, err := s.MarshalValue(namingEvent{HTTPServerID: "a"})
+		if err != nil || string(data) != `{"http_server_id":"a","TAGGED":"","o_auth2_token":"","count":0}` {
+			t.Error("expected derived JSON names when marshaling but received " + string(data))
+		}
+	})
+
+	t.Run("fold", func(t *testing.T) {
+		s := NewSchema().SetNamingStrategy(CamelCase)
+		event := s.GetStruct(namingEvent{})
+		if event.FieldByName("HTTPSERVERID") != nil {
+			t.Error("expected FieldByName to be case-sensitive")
+		}
+		if field := event.FieldByNameFold("HTTPSERVERID"); field == nil || field.Name != "HTTPServerID" {
+			t.Error("expected FieldByNameFold to match case-insensitively")
+		}
+		v, err := (&Converter{Schema: s, CaseInsensitive: true}).ConvertInterfaceValue(map[string]interface{}{"USERNAME": "ada", "count": 2}, namingEvent{})
+		if err != nil || v.(namingEvent).UserName != "ada" || v.(namingEvent).Count != 2 {
+			t.Error("expected case-insensitive conversion of map keys")
+		}
+		if _, err := s.ConvertInterfaceValue(map[string]interface{}{"USERNAME": "ada"}, namingEvent{}); err == nil {
+			t.Error("expected case-sensitive conversion of map keys by default")
+		}
+	})
+}
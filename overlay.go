@@ -0,0 +1,147 @@
+package typemeta
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+)
+
+// overlayType is the metadata of a type in an overlay
+type overlayType struct {
+	Description *string                 `json:"description"`
+	Deprecated  *string                 `json:"deprecated"`
+	Fields      map[string]overlayField `json:"fields"`
+	Values      map[string]string       `json:"values"`
+}
+
+// overlayField is the metadata of a struct field in an overlay
+type overlayField struct {
+	Description *string         `json:"description"`
+	Default     json.RawMessage `json:"default"`
+	Deprecated  *string         `json:"deprecated"`
+}
+
+// ApplyOverlay reads a JSON document of metadata and applies it to the type metas of the schema. The document is keyed by type name
+// (see `Lookup`), or by enum name for enums. Struct types can have a "description", a "deprecated" notice, and "fields" keyed by JSON
+// field name with a "description", a "default" value, and a "deprecated" notice. Enums can have "values" with a label for each value:
+//
+//	{
+//		"User": {
+//			"description": "A registered user",
+//			"fields": {"email": {"description": "Primary email address", "default": ""}}
+//		},
+//		"Status": {"values": {"active": "Active user"}}
+//	}
+//
+// Types must be known to the schema before the overlay is applied (see `Register`). Unknown types, fields, and values, as well as
+// unknown properties, are reported together as `Errors`, and the rest of the overlay is still applied.
+func (s *Schema) ApplyOverlay(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	overlay := map[string]overlayType{}
+	if err := decoder.Decode(&overlay); err != nil {
+		return errors.New("failed decoding overlay: " + err.Error())
+	}
+
+	var errs Errors
+	for _, typeName := range sortedKeys(overlay) {
+		ot := overlay[typeName]
+		t, ok := s.lookupOverlayType(typeName)
+		if !ok {
+			errs = append(errs, errors.New("overlay type \""+typeName+"\" does not exist in schema"))
+			continue
+		}
+		if ot.Description != nil || ot.Deprecated != nil || ot.Fields != nil {
+			strct, ok := t.(*Struct)
+			if !ok {
+				errs = append(errs, errors.New("overlay type \""+typeName+"\" is not a struct and cannot have a description, deprecation, or fields"))
+			} else {
+				errs = append(errs, s.applyStructOverlay(strct, ot)...)
+			}
+		}
+		if ot.Values != nil {
+			errs = append(errs, applyEnumOverlay(typeName, t, ot.Values)...)
+		}
+	}
+	return errorsOrNil(errs)
+}
+
+func (s *Schema) applyStructOverlay(strct *Struct, ot overlayType) Errors {
+	var errs Errors
+	if ot.Description != nil {
+		strct.SetDescription(*ot.Description)
+	}
+	if ot.Deprecated != nil {
+		strct.SetDeprecated(*ot.Deprecated)
+	}
+	for _, fieldName := range sortedKeys(ot.Fields) {
+		of := ot.Fields[fieldName]
+		field := strct.FieldByName(fieldName)
+		if field == nil || field.JSONName != fieldName {
+			errs = append(errs, errors.New("overlay field \""+fieldName+"\" does not exist in struct \""+strct.String()+"\""))
+			continue
+		}
+		if field.Depth() > 0 {
+			errs = append(errs, errors.New("overlay field \""+fieldName+"\" of struct \""+strct.String()+"\" is promoted from an embedded struct and has to be overlaid in that struct"))
+			continue
+		}
+		editor := strct.EditField(fieldName)
+		if of.Description != nil {
+			editor.Describe(*of.Description)
+		}
+		if of.Deprecated != nil {
+			editor.Deprecate(*of.Deprecated)
+		}
+		if of.Default != nil {
+			defaultValue, err := unmarshalValue(&Converter{Schema: s, ReadOnly: AllowReadOnly}, field.TypeMeta, of.Default)
+			if err != nil {
+				errs = append(errs, errors.New("invalid default value of overlay field \""+fieldName+"\" of struct \""+strct.String()+"\": "+err.Error()))
+				continue
+			}
+			editor.Default(defaultValue.Interface())
+		}
+	}
+	return errs
+}
+
+func applyEnumOverlay(typeName string, t TypeMeta, labels map[string]string) Errors {
+	var errs Errors
+	primitive, ok := t.(*Primitive)
+	if !ok || primitive.Enum() == nil {
+		return append(errs, errors.New("overlay type \""+typeName+"\" is not an enum and cannot have values"))
+	}
+	enum := primitive.Enum()
+	for _, name := range sortedKeys(labels) {
+		if _, ok := enum.values[name]; !ok {
+			errs = append(errs, errors.New("overlay value \""+name+"\" does not exist in enum \""+typeName+"\""))
+			continue
+		}
+		enum.SetLabel(name, labels[name])
+	}
+	return errs
+}
+
+// lookupOverlayType returns the type meta with the specified name, or the type meta of the enum with the specified name
+func (s *Schema) lookupOverlayType(name string) (TypeMeta, bool) {
+	if t, ok := s.Lookup(name); ok {
+		return t, true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for enum, t := range s.enumTypes {
+		if enum.name == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
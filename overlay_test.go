@@ -0,0 +1,85 @@
+package typemeta
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type overlayStatus string
+
+type overlayUser struct {
+	Email  string        `json:"email"`
+	Age    int           `json:"age"`
+	Status overlayStatus `json:"status"`
+}
+
+type overlayAdmin struct {
+	overlayUser
+	Level int      `json:"level" default:"1"`
+	Scope []string `json:"scope" default:"[\"read\"]"`
+	Team  int      `json:"team" default:"none"`
+}
+
+func TestApplyOverlay(t *testing.T) {
+	s := NewSchema()
+	user := s.GetStruct(overlayUser{})
+	s.GetPrimitive(overlayStatus("")).SetEnum(NewEnum("Status", []overlayStatus{"active", "banned"}))
+
+	err := s.ApplyOverlay(strings.NewReader(`{
+		"overlayUser": {
+			"description": "A registered user",
+			"deprecated": "use Account instead",
+			"fields": {
+				"email": {"description": "Primary email address"},
+				"age": {"default": 18, "deprecated": "use birthDate instead"}
+			}
+		},
+		"overlayStatus": {"values": {"active": "Active user"}}
+	}`))
+	if err != nil {
+		t.Fatal("failed applying overlay: " + err.Error())
+	}
+	if user.Description != "A registered user" || user.Deprecated != "use Account instead" {
+		t.Error("struct does not have the overlay metadata")
+	}
+	if user.EnsureFieldByName("email").Description != "Primary email address" {
+		t.Error("email field does not have the overlay description")
+	}
+	if age := user.EnsureFieldByName("age"); age.DefaultValue != 18 || age.Deprecated != "use birthDate instead" {
+		t.Error("age field does not have the overlay default value and deprecation")
+	}
+	if label := s.GetPrimitive(overlayStatus("")).Enum().Label("active"); label != "Active user" {
+		t.Error("enum value does not have the overlay label")
+	}
+
+	err = s.ApplyOverlay(strings.NewReader(`{
+		"overlayUser": {"fields": {"name": {}, "age": {"default": "old"}}},
+		"overlayStatus": {"values": {"deleted": "Deleted user"}},
+		"Account": {}
+	}`))
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 4 {
+		t.Error("expected 4 overlay errors but received " + err.Error())
+	}
+	if err := s.ApplyOverlay(strings.NewReader(`{"overlayUser": {"descripton": "typo"}}`)); err == nil {
+		t.Error("expected unknown properties to be reported")
+	}
+	admin := s.GetStruct(overlayAdmin{})
+	if level := admin.EnsureFieldByName("level"); level.DefaultValue != 1 {
+		t.Error("expected default value from tag to be parsed as the type of the field like overlay default values")
+	}
+	if scope := admin.EnsureFieldByName("scope").DefaultValue; scope == nil || scope.([]string)[0] != "read" {
+		t.Error("expected default value of slice field to be unmarshaled from JSON")
+	}
+	var fieldErr *FieldError
+	if !errors.As(s.Err(), &fieldErr) || fieldErr.Field != "Team" || admin.EnsureFieldByName("team").DefaultValue != nil {
+		t.Error("expected invalid default value to be reported and left out")
+	}
+	if err := s.ApplyOverlay(strings.NewReader(`{"overlayAdmin": {"fields": {"email": {"description": "Admin email"}}}}`)); err == nil {
+		t.Error("expected promoted fields to be reported")
+	}
+	if user.EnsureFieldByName("email").Description != "Primary email address" {
+		t.Error("overlay of a promoted field should not affect the embedded struct")
+	}
+}
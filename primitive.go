@@ -6,14 +6,19 @@ import (
 
 // Primitive is type meta for a primitive type
 type Primitive struct {
-	typ  reflect.Type
-	enum *Enum
-	name string
+	typ         reflect.Type
+	annotations *annotationSet
+	enum        *Enum
+	name        string
+	scalar      *ScalarSpec
+	codecs      Codec
+	schema      *Schema
 }
 
 // SetName sets the name of the struct type meta
 func (s *Primitive) SetName(name string) *Primitive {
 	s.name = name
+	schemaOf(s.schema).fieldsChanged()
 	return s
 }
 
@@ -28,6 +33,11 @@ func (s *Primitive) Enum() *Enum {
 	return s.enum
 }
 
+// Methods returns the methods of the primitive type, including methods with a pointer receiver
+func (s *Primitive) Methods() Methods {
+	return methodsOf(s.schema, s.typ)
+}
+
 // Name returns the type meta's explicitly set name or the type's name within its package for a defined type. For other (non-defined) types it returns the empty string.
 func (s *Primitive) Name() string {
 	if s.name != "" {
@@ -58,10 +68,15 @@ func (s *Primitive) JSONNonNull() bool {
 
 // Copy returns a copy of the primitive type meta
 func (s *Primitive) Copy() *Primitive {
-	ns := *s
+	ns := copyAnnotated(s)
+	ns.annotations = ns.annotations.clone()
 	return &ns
 }
 
 func (s *Primitive) String() string {
 	return s.Type().String()
 }
+
+func (s *Primitive) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
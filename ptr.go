@@ -8,7 +8,9 @@ import (
 type Ptr struct {
 	Elem TypeMeta
 
-	typ reflect.Type
+	typ         reflect.Type
+	annotations *annotationSet
+	schema      *Schema
 }
 
 // Layers returns the amount of layers of pointers the pointer is.
@@ -22,6 +24,11 @@ func (s *Ptr) Layers() int {
 	}
 }
 
+// Methods returns the methods of the pointer type, i.e. the methods of its element type with both pointer and value receivers
+func (s *Ptr) Methods() Methods {
+	return methodsOf(s.schema, s.typ)
+}
+
 // Type returns the type of the struct
 func (s *Ptr) Type() reflect.Type {
 	return s.typ
@@ -49,7 +56,8 @@ func (s *Ptr) Name() string {
 
 // Copy returns a copy of the ptr type meta
 func (s *Ptr) Copy() *Ptr {
-	ns := *s
+	ns := copyAnnotated(s)
+	ns.annotations = ns.annotations.clone()
 	return &ns
 }
 
@@ -57,3 +65,7 @@ func (s *Ptr) Copy() *Ptr {
 func (s *Ptr) String() string {
 	return s.Type().String()
 }
+
+func (s *Ptr) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -0,0 +1,164 @@
+package typemeta
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// RedactedString is the value of sensitive string fields in redacted values
+const RedactedString = "[REDACTED]"
+
+// Redact returns a deep copy of a value with the values of sensitive fields (see `StructField.Sensitive`) masked, using the default schema.
+// See `Schema.Redact`.
+func Redact(v interface{}) interface{} {
+	return DefaultSchema.Redact(v)
+}
+
+// Redact returns a deep copy of a value with the values of sensitive fields (see `StructField.Sensitive`) masked. Pointers, slices, arrays, maps,
+// interfaces, and nested structs are copied following their type metas, so the returned value can be logged without leaking sensitive values.
+// Sensitive fields of string kind, or pointers to string kind, are set to `RedactedString`, and other sensitive fields are set to their zero value.
+// Cyclic values are copied as cyclic values. Unexported fields cannot be set using reflection, so they are copied shallowly,
+// except for the exported fields of unexported embedded structs.
+func (s *Schema) Redact(v interface{}) interface{} {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() {
+		return v
+	}
+	r := redactor{schema: s, copies: map[visit]reflect.Value{}}
+	return r.redact(rv, s.Get(rv.Type())).Interface()
+}
+
+// redactor copies values with sensitive fields masked
+type redactor struct {
+	schema *Schema
+	copies map[visit]reflect.Value // copies of pointers, slices, and maps, so cycles are copied as cycles
+}
+
+func (r *redactor) redact(value reflect.Value, tm TypeMeta) reflect.Value {
+	switch t := tm.(type) {
+	case *Ptr:
+		if value.IsNil() {
+			return value
+		}
+		v := visit{value.Pointer(), value.Type(), 0}
+		if copied, ok := r.copies[v]; ok {
+			return copied
+		}
+		copied := reflect.New(value.Type().Elem())
+		r.copies[v] = copied
+		copied.Elem().Set(r.redact(value.Elem(), t.Elem))
+		return copied
+	case *Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(r.redact(value.Elem(), r.schema.Get(value.Elem().Type())))
+		return copied
+	case *Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		r.redactFields(copied, value, t)
+		return copied
+	case *Slice:
+		if value.IsNil() {
+			return value
+		}
+		v := visit{value.Pointer(), value.Type(), value.Len()}
+		if copied, ok := r.copies[v]; ok {
+			return copied
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		r.copies[v] = copied
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(r.redact(value.Index(i), t.Elem))
+		}
+		return copied
+	case *Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(r.redact(value.Index(i), t.Elem))
+		}
+		return copied
+	case *Map:
+		if value.IsNil() {
+			return value
+		}
+		v := visit{value.Pointer(), value.Type(), 0}
+		if copied, ok := r.copies[v]; ok {
+			return copied
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		r.copies[v] = copied
+		mapIter := value.MapRange()
+		for mapIter.Next() {
+			copied.SetMapIndex(mapIter.Key(), r.redact(mapIter.Value(), t.Elem))
+		}
+		return copied
+	default:
+		return value
+	}
+}
+
+// redactFields sets the fields of a copied struct to redacted copies of the fields of the struct value
+func (r *redactor) redactFields(copied reflect.Value, value reflect.Value, strct *Struct) {
+	for fieldIndex := 0; fieldIndex < value.NumField(); fieldIndex++ {
+		field := strct.Fields[fieldIndex]
+		copiedField := copied.Field(fieldIndex)
+		if !copiedField.CanSet() {
+			if embeddedStruct, ok := field.TypeMeta.(*Struct); ok && field.Anonymous {
+				// the exported fields of an unexported embedded struct can be set
+				r.redactFields(copiedField, value.Field(fieldIndex), embeddedStruct)
+			}
+			continue
+		}
+		if field.Sensitive {
+			copiedField.Set(redactedValue(copiedField.Type()))
+		} else {
+			copiedField.Set(r.redact(value.Field(fieldIndex), field.TypeMeta))
+		}
+	}
+}
+
+// redactedValue returns the masked value of a sensitive field of the specified type
+func redactedValue(typ reflect.Type) reflect.Value {
+	switch {
+	case typ.Kind() == reflect.String:
+		return reflect.ValueOf(RedactedString).Convert(typ)
+	case typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.String:
+		ptr := reflect.New(typ.Elem())
+		ptr.Elem().Set(redactedValue(typ.Elem()))
+		return ptr
+	default:
+		return reflect.Zero(typ)
+	}
+}
+
+// Redacted wraps a value so that it is formatted in its redacted form (see `Redact`), e.g. `log.Printf("%+v", typemeta.Redacted{Value: request})`
+type Redacted struct {
+	Value  interface{}
+	Schema *Schema // Schema of the type metas, or nil for the default schema
+}
+
+// String returns the redacted value formatted using `fmt.Sprint`
+func (r Redacted) String() string {
+	return fmt.Sprint(schemaOf(r.Schema).Redact(r.Value))
+}
+
+// Format formats the redacted value using the verb, flags, width, and precision of the format
+func (r Redacted) Format(f fmt.State, verb rune) {
+	format := "%"
+	for _, flag := range "+-# 0" {
+		if f.Flag(int(flag)) {
+			format += string(flag)
+		}
+	}
+	if width, ok := f.Width(); ok {
+		format += strconv.Itoa(width)
+	}
+	if precision, ok := f.Precision(); ok {
+		format += "." + strconv.Itoa(precision)
+	}
+	fmt.Fprintf(f, format+string(verb), schemaOf(r.Schema).Redact(r.Value))
+}
@@ -0,0 +1,82 @@
+package typemeta
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type redactCredentials struct {
+	Token  string `sensitive:"true"`
+	Expiry int
+}
+
+type redactBase struct {
+	APIKey *string `sensitive:""`
+}
+
+type redactRequest struct {
+	redactBase
+	User        string
+	Password    string `sensitive:"true"`
+	PIN         int    `sensitive:"true"`
+	Credentials []*redactCredentials
+	Headers     map[string]redactCredentials
+	Extra       interface{}
+	Parent      *redactRequest
+}
+
+func TestRedact(t *testing.T) {
+	s := NewSchema()
+	apiKey := "key"
+	request := &redactRequest{
+		redactBase:  redactBase{APIKey: &apiKey},
+		User:        "ada",
+		Password:    "secret",
+		PIN:         1234,
+		Credentials: []*redactCredentials{{Token: "t1", Expiry: 5}},
+		Headers:     map[string]redactCredentials{"auth": {Token: "t2"}},
+		Extra:       redactCredentials{Token: "t3"},
+	}
+	request.Parent = request
+
+	redacted := s.Redact(request).(*redactRequest)
+
+	t.Run("masked", func(t *testing.T) {
+		if redacted.User != "ada" || redacted.Password != RedactedString || redacted.PIN != 0 || *redacted.APIKey != RedactedString {
+			t.Error("expected sensitive fields to be masked")
+		}
+		if redacted.Credentials[0].Token != RedactedString || redacted.Credentials[0].Expiry != 5 {
+			t.Error("expected sensitive fields of slice elements to be masked")
+		}
+		if redacted.Headers["auth"].Token != RedactedString || redacted.Extra.(redactCredentials).Token != RedactedString {
+			t.Error("expected sensitive fields of map elements and interfaces to be masked")
+		}
+	})
+
+	t.Run("copied", func(t *testing.T) {
+		if request.Password != "secret" || apiKey != "key" || request.Credentials[0].Token != "t1" || request.Headers["auth"].Token != "t2" {
+			t.Error("expected the original value to be left unchanged")
+		}
+		if redacted.Parent != redacted {
+			t.Error("expected cyclic value to be copied as a cyclic value")
+		}
+	})
+
+	t.Run("editor", func(t *testing.T) {
+		s.GetStruct(redactCredentials{}).EditField("Expiry").Sensitive()
+		if s.Redact(redactCredentials{Expiry: 5}).(redactCredentials).Expiry != 0 {
+			t.Error("expected field marked as sensitive using the editor to be masked")
+		}
+	})
+
+	t.Run("formatted", func(t *testing.T) {
+		r := Redacted{Value: redactCredentials{Token: "t1"}, Schema: s}
+		if str := fmt.Sprintf("%+v", r); str != "{Token:[REDACTED] Expiry:0}" {
+			t.Error("expected redacted formatting but received " + str)
+		}
+		if str := r.String(); strings.Contains(str, "t1") {
+			t.Error("expected redacted string but received " + str)
+		}
+	})
+}
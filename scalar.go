@@ -0,0 +1,94 @@
+package typemeta
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ScalarSpec specifies a custom scalar type, e.g. a UUID, decimal, or ID type, which is treated as a primitive regardless of its kind
+type ScalarSpec struct {
+	Name string // Name of the scalar, e.g. "DateTime" for GraphQL `scalar DateTime`, or the empty string for the name of the type
+	// Parse parses a value of another type, e.g. a string from JSON, into a value of the scalar type (or a pointer to one)
+	Parse func(value interface{}) (interface{}, error)
+	// Serialize serializes a value of the scalar type into a value representable in JSON, e.g. a string
+	Serialize func(value interface{}) (interface{}, error)
+	Format    string // Format of the serialized values, e.g. "uuid" or "date-time" (for API schemas etc.)
+}
+
+// RegisterScalar registers a type as a custom scalar, meaning that its type meta is a `*Primitive` with the specified scalar spec and name.
+// Values of other types are converted to the scalar type using Parse, and values of the scalar type are converted to other types and
+// marshaled (see `MarshalValue`) using Serialize. A scalar has to be registered before type meta is retrieved for the type, since type metas
+// may be used concurrently once retrieved, and RegisterScalar panics otherwise. Scalars may be of any kind, e.g. an array type like `[16]byte` for UUIDs,
+// and their Parse and Serialize functions take precedence over the codec methods of the type (see `Primitive.Codecs`).
+func (s *Schema) RegisterScalar(typ interface{}, spec ScalarSpec) *Primitive {
+	rtyp, ok := typ.(reflect.Type)
+	if !ok {
+		rtyp = reflect.TypeOf(typ)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.types[rtyp] != nil {
+		panic("Scalar type " + rtyp.String() + " has to be registered before type meta is retrieved for it")
+	}
+	p := &Primitive{typ: rtyp, name: spec.Name, scalar: &spec, codecs: codecsOf(rtyp), schema: s}
+	s.types[rtyp] = p
+	return p
+}
+
+// Scalar returns a copy of the scalar spec of the primitive type and true if it is a custom scalar (see `Schema.RegisterScalar`), or false otherwise.
+// Exporters of API schemas use the name of the type meta as the name of the scalar, e.g. GraphQL `scalar DateTime`, and the
+// format of the spec as the format of the values (see `Format`).
+func (s *Primitive) Scalar() (ScalarSpec, bool) {
+	if s.scalar == nil {
+		return ScalarSpec{}, false
+	}
+	return *s.scalar, true
+}
+
+// Format returns the format of the values of the primitive type for API schemas, e.g. "uuid" for an OpenAPI `format`, as specified
+// when registering it as a custom scalar (see `Schema.RegisterScalar`). For other primitive types it returns the empty string.
+func (s *Primitive) Format() string {
+	if s.scalar == nil {
+		return ""
+	}
+	return s.scalar.Format
+}
+
+// scalarOf returns the scalar spec of a type meta if it is a custom scalar, or nil otherwise
+func scalarOf(t TypeMeta) *ScalarSpec {
+	if p, ok := t.(*Primitive); ok {
+		return p.scalar
+	}
+	return nil
+}
+
+// parseScalar converts a value to a custom scalar type using the Parse function of the scalar
+func parseScalar(value reflect.Value, toTypeMeta *Primitive) (reflect.Value, error) {
+	parsed, err := toTypeMeta.scalar.Parse(value.Interface())
+	if err != nil {
+		return value, errors.New("could not parse " + fmt.Sprint(value.Interface()) + " as " + toTypeMeta.String() + ": " + err.Error())
+	}
+	parsedValue := reflect.ValueOf(parsed)
+	if parsedValue.Kind() == reflect.Ptr && parsedValue.Type().Elem() == toTypeMeta.typ && !parsedValue.IsNil() {
+		parsedValue = parsedValue.Elem()
+	}
+	if !parsedValue.IsValid() || parsedValue.Type() != toTypeMeta.typ {
+		return value, errors.New("parser of scalar " + toTypeMeta.String() + " returned " + fmt.Sprintf("%T", parsed) + " instead of " + toTypeMeta.String())
+	}
+	return parsedValue, nil
+}
+
+// serializeScalar serializes a value of a custom scalar type using the Serialize function of the scalar
+func serializeScalar(value reflect.Value, valueTypeMeta *Primitive) (reflect.Value, error) {
+	serialized, err := valueTypeMeta.scalar.Serialize(value.Interface())
+	if err != nil {
+		return value, errors.New("could not serialize " + valueTypeMeta.String() + ": " + err.Error())
+	}
+	serializedValue := reflect.ValueOf(serialized)
+	if serializedValue.IsValid() && (serializedValue.Type() == valueTypeMeta.typ || serializedValue.Type() == reflect.PtrTo(valueTypeMeta.typ)) {
+		// serializing the serialized value would never end
+		return value, errors.New("serializer of scalar " + valueTypeMeta.String() + " returned " + serializedValue.Type().String() + " instead of another type")
+	}
+	return serializedValue, nil
+}
@@ -0,0 +1,157 @@
+package typemeta
+
+import (
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+type scalarUUID [16]byte
+
+func (u scalarUUID) String() string {
+	return hex.EncodeToString(u[:])
+}
+
+type scalarID struct {
+	value int
+}
+
+type scalarRecord struct {
+	ID     scalarID    `json:"id"`
+	UUID   scalarUUID  `json:"uuid"`
+	Parent *scalarUUID `json:"parent"`
+}
+
+func TestRegisterScalar(t *testing.T) {
+	s := NewSchema()
+	uuid := s.RegisterScalar(scalarUUID{}, ScalarSpec{
+		Name:   "UUID",
+		Format: "uuid",
+		Parse: func(value interface{}) (interface{}, error) {
+			str, ok := value.(string)
+			if !ok {
+				return nil, errors.New("expected string")
+			}
+			var u scalarUUID
+			data, err := hex.DecodeString(strings.ReplaceAll(str, "-", ""))
+			if err != nil || len(data) != len(u) {
+				return nil, errors.New("invalid UUID")
+			}
+			copy(u[:], data)
+			return &u, nil
+		},
+		Serialize: func(value interface{}) (interface{}, error) {
+			return value.(scalarUUID).String(), nil
+		},
+	})
+	s.RegisterScalar(scalarID{}, ScalarSpec{
+		Parse: func(value interface{}) (interface{}, error) {
+			switch value := value.(type) {
+			case float64:
+				return scalarID{int(value)}, nil
+			case string:
+				id, err := strconv.Atoi(strings.TrimPrefix(value, "id-"))
+				return scalarID{id}, err
+			}
+			return nil, errors.New("expected number or string")
+		},
+		Serialize: func(value interface{}) (interface{}, error) {
+			return "id-" + strconv.Itoa(value.(scalarID).value), nil
+		},
+	})
+	uuidStr := "00112233445566778899aabbccddeeff"
+
+	t.Run("meta", func(t *testing.T) {
+		if s.Get(scalarUUID{}) != uuid || !uuid.Primitive() {
+			t.Error("expected registered scalar to be primitive type meta")
+		}
+		if uuid.Name() != "UUID" || uuid.Format() != "uuid" || s.GetPrimitive("").Format() != "" {
+			t.Error("expected scalar name and format but received " + uuid.Name())
+		}
+		if id, ok := s.Get(scalarID{}).(*Primitive); !ok || id.Name() != "scalarID" {
+			t.Error("expected registered struct to be primitive type meta with the name of the type")
+		}
+		if spec, ok := uuid.Scalar(); !ok || spec.Format != "uuid" {
+			t.Error("expected spec of registered scalar")
+		} else if spec.Parse = nil; uuid.scalar.Parse == nil {
+			t.Error("expected a copy of the spec of the registered scalar")
+		}
+		if _, ok := s.GetPrimitive("").Scalar(); ok {
+			t.Error("expected no spec of other primitive types")
+		}
+		if _, ok := NewSchema().Get(scalarUUID{}).(*Primitive); ok {
+			t.Error("expected scalar to be registered only for the schema")
+		}
+	})
+
+	t.Run("convert", func(t *testing.T) {
+		u, err := s.ConvertInterfaceValue(uuidStr, scalarUUID{})
+		if err != nil || u.(scalarUUID).String() != uuidStr {
+			t.Error("expected string to be parsed as scalar")
+		}
+		str, err := s.ConvertInterfaceValue(u, "")
+		if err != nil || str != uuidStr {
+			t.Error("expected scalar to be serialized as string")
+		}
+		if _, err := s.ConvertInterfaceValue("uuid", scalarUUID{}); err == nil {
+			t.Error("expected parse error")
+		}
+		id, err := s.ConvertInterfaceValue(scalarID{3}, "")
+		if err != nil || id != "id-3" {
+			t.Error("expected serialized scalar to be converted")
+		}
+	})
+
+	t.Run("unmarshal", func(t *testing.T) {
+		v, err := s.UnmarshalValue(scalarRecord{}, []byte(`{"id":"id-5","uuid":"`+uuidStr+`","parent":"`+uuidStr+`"}`))
+		if err != nil {
+			t.Fatal("failed unmarshaling value: " + err.Error())
+		}
+		record := v.(scalarRecord)
+		if record.ID.value != 5 || record.UUID.String() != uuidStr || record.Parent == nil || *record.Parent != record.UUID {
+			t.Error("expected scalars to be parsed")
+		}
+		data, err := s.MarshalValue(record)
+		expected := `{"id":"id-5","uuid":"` + uuidStr + `","parent":"` + uuidStr + `"}`
+		if err != nil || string(data) != expected {
+			t.Error("expected " + expected + " but received " + string(data))
+		}
+	})
+
+	t.Run("built", func(t *testing.T) {
+		type built struct{ A int }
+		type builtID int
+		s.Get(built{})
+		s.Get(builtID(0))
+		for _, typ := range []interface{}{built{}, builtID(0)} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("expected registering a built type to panic")
+					}
+				}()
+				s.RegisterScalar(typ, ScalarSpec{Name: "ID"})
+			}()
+		}
+		if s.GetPrimitive(builtID(0)).Name() != "builtID" {
+			t.Error("expected built primitive not to be changed")
+		}
+	})
+
+	t.Run("serialized to itself", func(t *testing.T) {
+		type selfScalar struct{ A int }
+		s.RegisterScalar(selfScalar{}, ScalarSpec{
+			Serialize: func(value interface{}) (interface{}, error) {
+				return value, nil
+			},
+		})
+		if _, err := s.ConvertInterfaceValue(selfScalar{1}, ""); err == nil {
+			t.Error("expected scalar serialized to itself to fail converting")
+		}
+		if _, err := s.MarshalValue(selfScalar{1}); err == nil {
+			t.Error("expected scalar serialized to itself to fail marshaling")
+		}
+	})
+}
@@ -2,8 +2,11 @@ package typemeta
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"unicode"
 
 	"github.com/fatih/structtag"
@@ -11,9 +14,19 @@ import (
 
 // Schema is a schema of type meta
 type Schema struct {
-	mu        sync.Mutex
-	types     map[reflect.Type]TypeMeta
-	enumTypes map[*Enum]TypeMeta
+	generation     uint64 // fields generation, first for the alignment of atomic operations (see `fieldsChanged`)
+	mu             sync.Mutex
+	types          map[reflect.Type]TypeMeta
+	enumTypes      map[*Enum]TypeMeta
+	typeNamePolicy TypeNamePolicy
+	namingStrategy NamingStrategy
+	nameTag        atomic.Value // string, read without locking the schema (see `NameTag`)
+	tagHandlers    map[string][]TagHandler
+	comments       map[string]typeComments
+	errs           Errors
+	built          []reflect.Type // types of the type metas being built while the mutex is locked (see `get`)
+	pending        []tagValue     // tag examples and default values of the fields of the type metas being built
+	index          *typeIndex
 }
 
 // Get returns type meta for the specified type. If type meta is passed, that is returned. If a reflect type is passed,
@@ -38,17 +51,22 @@ func (s *Schema) Get(typ interface{}) TypeMeta {
 		}
 		panic("No default type has been defined for kind " + typ.String())
 	case *Enum:
-		if s.enumTypes[typ] != nil {
-			return s.enumTypes[typ]
+		s.mu.Lock()
+		enumType := s.enumTypes[typ]
+		s.mu.Unlock()
+		if enumType != nil {
+			return enumType
 		}
 		r, ok := s.get(typ.typ, false).(*Primitive)
 		if !ok {
-			r = &Primitive{typ: typ.typ, enum: typ}
+			r = &Primitive{typ: typ.typ, enum: typ, schema: s}
 		} else {
 			r = r.Copy()
 			r.enum = typ
 		}
+		s.mu.Lock()
 		s.enumTypes[typ] = r
+		s.mu.Unlock()
 		return r
 	default:
 		return s.get(reflect.TypeOf(typ), false)
@@ -71,130 +89,259 @@ func (s *Schema) PtrTo(typ interface{}) *Ptr {
 	return ptr
 }
 
+// get returns the type meta of a type, building it if it is not known to the schema. If locked is false, the schema mutex is locked while
+// building the type meta, and the type metas built along with it are published once the tag examples and default values of their fields
+// have been parsed. They are parsed without the mutex locked, since parsing them may run user code (e.g. scalar parsers) calling methods of the schema.
 func (s *Schema) get(rtyp reflect.Type, locked bool) TypeMeta {
-	s.lock(locked)
+	if locked {
+		return s.build(rtyp)
+	}
+	for {
+		s.mu.Lock()
+		errIndex := len(s.errs)
+		meta := s.build(rtyp)
+		b := s.unpublish(errIndex)
+		s.mu.Unlock()
+		if b == nil || s.publish(b) {
+			return meta
+		}
+		// type metas of the built types were added while parsing tag values, e.g. by another goroutine, and are retrieved instead
+	}
+}
+
+// build returns the type meta of a type, building it if it is not known to the schema. The schema mutex must be locked.
+func (s *Schema) build(rtyp reflect.Type) TypeMeta {
 	meta := s.types[rtyp]
 	if meta != nil {
-		s.unlock(locked)
 		return meta
 	}
 	if rtyp == nil {
 		i := &Interface{}
-		s.types[rtyp] = i
-		s.unlock(locked)
+		s.add(rtyp, i)
 		return i
 	}
+	if codecPrimitive(rtyp) {
+		// struct, array, or slice encoded by its own codec methods
+		p := &Primitive{typ: rtyp, codecs: codecsOf(rtyp), schema: s}
+		s.add(rtyp, p)
+		return p
+	}
 	switch rtyp.Kind() {
 	case reflect.Ptr:
-		ptr := &Ptr{typ: rtyp}
-		s.types[rtyp] = ptr
+		ptr := &Ptr{typ: rtyp, schema: s}
+		s.add(rtyp, ptr)
 		ptr.Elem = s.get(rtyp.Elem(), true)
-		s.unlock(locked)
 		return ptr
 	case reflect.Struct:
-		if rtyp.Implements(primitiveType) {
-			// primitive struct
-			p := &Primitive{rtyp, nil, ""}
-			s.types[rtyp] = p
-			s.unlock(locked)
-			return p
-		}
-		strct := &Struct{Fields: map[int]StructField{}, typ: rtyp}
-		s.types[rtyp] = strct
+		strct := &Struct{Fields: map[int]StructField{}, typ: rtyp, schema: s, visible: &visibleFieldsCache{}}
+		s.add(rtyp, strct)
 		for fieldIndex := 0; fieldIndex < rtyp.NumField(); fieldIndex++ {
-			rsf := rtyp.Field(fieldIndex)
-			nameFirstChar := []rune(rsf.Name)[0]
-			private := unicode.IsLower(nameFirstChar) || nameFirstChar == underscoreChar
-			field := StructField{
-				Name:         rsf.Name,
-				Index:        fieldIndex,
-				Anonymous:    rsf.Anonymous,
-				Private:      private,
-				JSONExcluded: private,
-				TypeMeta:     s.get(rsf.Type, true),
-			}
-
-			tags, err := structtag.Parse(string(rsf.Tag))
-			if err != nil {
-				panic("failed parsing tags <" + string(rsf.Tag) + "> of field \"" + field.String() + "\": " + err.Error())
-			}
-			field.Tags = tags
-			if jsonTag, _ := tags.Get("json"); jsonTag != nil {
-				if jsonTag.Name != "" {
-					if jsonTag.Name != "-" {
-						field.JSONName = jsonTag.Name
-					} else {
-						field.JSONExcluded = true
-					}
-				} else {
-					field.JSONName = rsf.Name
-				}
-				if jsonTag.HasOption("omitempty") {
-					field.JSONOmitEmpty = true
-				}
-			} else if !field.JSONExcluded {
-				field.JSONName = rsf.Name
-			}
-			if defaultValueTag, err := tags.Get("default"); defaultValueTag != nil && err == nil {
-				defaultValueStr := defaultValueTag.Name
-				// defaultReflectValue, err := field.ParseReflectValue(reflect.ValueOf(defaultValueStr))
-				// if err != nil {
-				// 	panic("Unable to convert default value in tag of field \"" + field.String() + "\": " + err.Error())
-				// }
-				field.DefaultValue = defaultValueStr
-			}
-			if descriptionTag, err := tags.Get("description"); descriptionTag != nil && err == nil {
-				field.Description = descriptionTag.Value()
-			}
-
-			strct.Fields[fieldIndex] = field
+			strct.Fields[fieldIndex] = s.structField(strct, fieldIndex)
 		}
-		s.unlock(locked)
+		s.applyComments(strct)
 		return strct
 	case reflect.Map:
 		mp := &Map{typ: rtyp}
-		s.types[rtyp] = mp
+		s.add(rtyp, mp)
 		mp.Key = s.get(rtyp.Key(), true)
 		mp.Elem = s.get(rtyp.Elem(), true)
-		s.unlock(locked)
 		return mp
 	case reflect.Slice:
 		sl := &Slice{typ: rtyp}
-		s.types[rtyp] = sl
+		s.add(rtyp, sl)
 		sl.Elem = s.get(rtyp.Elem(), true)
-		s.unlock(locked)
 		return sl
 	case reflect.Array:
 		arr := &Array{typ: rtyp}
-		s.types[rtyp] = arr
+		s.add(rtyp, arr)
 		arr.Elem = s.get(rtyp.Elem(), true)
-		s.unlock(locked)
 		return arr
+	case reflect.Func:
+		fn := &Func{typ: rtyp, Variadic: rtyp.IsVariadic()}
+		s.add(rtyp, fn)
+		for i := 0; i < rtyp.NumIn(); i++ {
+			fn.In = append(fn.In, s.get(rtyp.In(i), true))
+		}
+		for i := 0; i < rtyp.NumOut(); i++ {
+			fn.Out = append(fn.Out, s.get(rtyp.Out(i), true))
+		}
+		return fn
+	case reflect.Chan:
+		ch := &Chan{typ: rtyp, Dir: rtyp.ChanDir()}
+		s.add(rtyp, ch)
+		ch.Elem = s.get(rtyp.Elem(), true)
+		return ch
+	case reflect.UnsafePointer:
+		p := &UnsafePointer{typ: rtyp}
+		s.add(rtyp, p)
+		return p
 	case reflect.Interface:
-		i := &Interface{rtyp}
-		s.types[rtyp] = i
-		s.unlock(locked)
+		i := &Interface{typ: rtyp}
+		s.add(rtyp, i)
 		return i
 	default:
-		p := &Primitive{rtyp, nil, ""}
-		s.types[rtyp] = p
-		s.unlock(locked)
+		p := &Primitive{typ: rtyp, codecs: codecsOf(rtyp), schema: s}
+		s.add(rtyp, p)
 		return p
 	}
 }
 
-// locks to schema mutex if locked is false
-func (s *Schema) lock(locked bool) {
-	if !locked {
-		s.mu.Lock()
+// structField returns type meta for the field at the specified index of a struct. The schema mutex must be locked.
+func (s *Schema) structField(strct *Struct, fieldIndex int) StructField {
+	rsf := strct.typ.Field(fieldIndex)
+	nameFirstChar := []rune(rsf.Name)[0]
+	private := unicode.IsLower(nameFirstChar) || nameFirstChar == underscoreChar
+	field := StructField{
+		Name:         rsf.Name,
+		Index:        fieldIndex,
+		IndexPath:    []int{fieldIndex},
+		annotations:  &annotationSet{},
+		Anonymous:    rsf.Anonymous,
+		Private:      private,
+		JSONExcluded: private,
+		TypeMeta:     s.get(rsf.Type, true),
+	}
+
+	tags, err := structtag.Parse(string(rsf.Tag))
+	if err != nil {
+		s.errs = append(s.errs, &FieldError{strct, field.Name, errors.New("failed parsing tags <" + string(rsf.Tag) + ">: " + err.Error())})
+		tags = &structtag.Tags{}
 	}
+	field.Tags = tags
+	if jsonUnsupported(field.TypeMeta) {
+		field.JSONExcluded = true
+	}
+	if jsonTag, _ := tags.Get("json"); jsonTag != nil {
+		if jsonTag.Name != "" {
+			if jsonTag.Name != "-" {
+				field.JSONName = jsonTag.Name
+				field.jsonNamed = true
+			} else {
+				field.JSONExcluded = true
+			}
+		} else {
+			field.JSONName = s.derivedJSONName(rsf.Name)
+		}
+		if jsonTag.HasOption("omitempty") {
+			field.JSONOmitEmpty = true
+		}
+		if jsonTag.HasOption("string") && jsonQuotable(field.TypeMeta) {
+			field.JSONString = true
+		}
+	} else if !field.JSONExcluded {
+		field.JSONName = s.derivedJSONName(rsf.Name)
+	}
+	field.Formats = make(map[string]FieldFormat, len(formatTags))
+	for _, formatTag := range formatTags {
+		field.Formats[formatTag] = parseFieldFormat(tags, formatTag, rsf.Name, private)
+	}
+	if defaultValueTag, err := tags.Get("default"); defaultValueTag != nil && err == nil {
+		// parsed like examples once the type meta of the field is complete
+		s.pending = append(s.pending, tagValue{strct, fieldIndex, "default", defaultValueTag.Value()})
+	}
+	if descriptionTag, err := tags.Get("description"); descriptionTag != nil && err == nil {
+		field.Description = descriptionTag.Value()
+	}
+	if deprecatedTag, err := tags.Get("deprecated"); deprecatedTag != nil && err == nil {
+		field.Deprecated = deprecatedTag.Value()
+		if field.Deprecated == "" {
+			// a notice is optional
+			field.Deprecated = "deprecated"
+		}
+	}
+	if sensitiveTag, err := tags.Get("sensitive"); sensitiveTag != nil && err == nil {
+		if sensitiveTag.Value() == "" {
+			field.Sensitive = true
+		} else if sensitive, err := strconv.ParseBool(sensitiveTag.Value()); err != nil {
+			s.errs = append(s.errs, &FieldError{strct, field.Name, errors.New("invalid sensitive tag: expected a boolean")})
+		} else {
+			field.Sensitive = sensitive
+		}
+	}
+	if aliasTag, err := tags.Get("alias"); aliasTag != nil && err == nil {
+		for _, alias := range append([]string{aliasTag.Name}, aliasTag.Options...) {
+			if alias != "" {
+				field.Aliases = append(field.Aliases, alias)
+			}
+		}
+	}
+	if accessTag, err := tags.Get("access"); accessTag != nil && err == nil {
+		if access, err := parseAccess(accessTag.Value()); err != nil {
+			s.errs = append(s.errs, &FieldError{strct, field.Name, errors.New("invalid access tag: " + err.Error())})
+		} else {
+			field.Access = access
+		}
+	}
+
+	for _, tag := range tags.Tags() {
+		if tag.Key == "example" {
+			// parsed once the type meta of the field is complete
+			s.pending = append(s.pending, tagValue{strct, fieldIndex, tag.Key, tag.Value()})
+		}
+		for _, handler := range s.tagHandlers[tag.Key] {
+			if err := handler(&field, tag); err != nil {
+				s.errs = append(s.errs, &FieldError{strct, field.Name, errors.New("invalid " + tag.Key + " tag: " + err.Error())})
+			}
+		}
+	}
+	return field
 }
 
-// unlocks to schema mutex if locked is false
-func (s *Schema) unlock(locked bool) {
-	if !locked {
-		s.mu.Unlock()
+// add adds the type meta of a type being built to the schema. The schema mutex must be locked.
+func (s *Schema) add(rtyp reflect.Type, meta TypeMeta) {
+	s.types[rtyp] = meta
+	s.built = append(s.built, rtyp)
+}
+
+// unpublished is the type metas built by a single call to `get` that have yet to be published, along with the tag values to parse
+// and the errors reported while building them
+type unpublished struct {
+	types  map[reflect.Type]TypeMeta
+	values []tagValue
+	errs   Errors
+}
+
+// unpublish removes the built type metas from the schema if tag examples or default values of their fields have to be parsed, and returns them.
+// The errors reported since errIndex are held back until the type metas are published. The schema mutex must be locked.
+func (s *Schema) unpublish(errIndex int) *unpublished {
+	built, values := s.built, s.pending
+	s.built, s.pending = nil, nil
+	if len(values) == 0 {
+		return nil
+	}
+	b := &unpublished{types: make(map[reflect.Type]TypeMeta, len(built)), values: values, errs: append(Errors(nil), s.errs[errIndex:]...)}
+	s.errs = s.errs[:errIndex]
+	for _, rtyp := range built {
+		b.types[rtyp] = s.types[rtyp]
+		delete(s.types, rtyp)
 	}
+	return b
+}
+
+// publish parses the tag examples and default values of the built type metas and then adds the type metas to the schema at once. The schema mutex must not
+// be locked. Returns false if type metas of any of the built types have been added to the schema in the meantime, discarding the build.
+func (s *Schema) publish(b *unpublished) bool {
+	for _, value := range b.values {
+		s.addTagValue(b, value)
+	}
+	for _, t := range b.types {
+		if strct, ok := t.(*Struct); ok {
+			// visible fields may have been cached without parsed values while parsing
+			strct.visible = &visibleFieldsCache{}
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for rtyp := range b.types {
+		if s.types[rtyp] != nil {
+			return false
+		}
+	}
+	for rtyp, t := range b.types {
+		s.types[rtyp] = t
+	}
+	s.errs = append(s.errs, b.errs...)
+	return true
 }
 
 // GetPrimitive is `Get` but asserts the returned type meta to `*Primitive`, meaning it panics if the specified type is not primitive.
@@ -215,9 +362,152 @@ func (s *Schema) GetStruct(typ interface{}) *Struct {
 	return t
 }
 
+// GetFunc is `Get` but asserts the returned type meta to `*Func`, meaning it panics if the specified type is not a function.
+// If a function value is passed, a copy of the type meta is returned with its `FuncName` set to the name of the function.
+func (s *Schema) GetFunc(typ interface{}) *Func {
+	t, ok := s.Get(typ).(*Func)
+	if !ok {
+		panic(s.Get(typ).String() + " is not a func type")
+	}
+	if funcName := FuncName(typ); funcName != "" {
+		t = t.Copy()
+		t.funcName = funcName
+	}
+	return t
+}
+
+// Register retrieves type meta for each of the specified types (see `Get`), making them known to the schema up front.
+// The errors reported while building the type metas are returned (see `Err`).
+func (s *Schema) Register(types ...interface{}) error {
+	s.mu.Lock()
+	errIndex := len(s.errs)
+	s.mu.Unlock()
+	for _, typ := range types {
+		s.Get(typ)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errorsOrNil(s.errs[errIndex:len(s.errs):len(s.errs)])
+}
+
+// Err returns the errors reported while building the type metas of the schema, such as malformed struct tags, as `Errors`.
+// Building a type meta never fails, so the type metas are still available. If no errors have been reported, nil is returned.
+func (s *Schema) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return errorsOrNil(s.errs[:len(s.errs):len(s.errs)])
+}
+
+// RegisterTagHandler registers a handler of a struct tag key, e.g. "unit" for `unit:"ms"`. The handlers of a key are called in the order they
+// were registered for each field with the tag, when the struct is first built by the schema. A handler may change the field or annotate it
+// (see `Annotate`), but must not call methods of the schema. An error returned by a handler is reported as a `*FieldError` (see `Err`).
+// Handlers are not called for structs that have already been built.
+func (s *Schema) RegisterTagHandler(key string, handler TagHandler) *Schema {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tagHandlers == nil {
+		s.tagHandlers = map[string][]TagHandler{}
+	}
+	s.tagHandlers[key] = append(s.tagHandlers[key], handler)
+	return s
+}
+
+// Types returns the type metas known to the schema, sorted by qualified name and then by string representation
+func (s *Schema) Types() []TypeMeta {
+	s.mu.Lock()
+	types := make([]TypeMeta, 0, len(s.types))
+	for rtyp, t := range s.types {
+		if rtyp != nil {
+			types = append(types, t)
+		}
+	}
+	s.mu.Unlock()
+	sortTypes(types)
+	return types
+}
+
+// TypesOfKind returns the type metas known to the schema with any of the specified kinds, sorted like `Types`
+func (s *Schema) TypesOfKind(kinds ...reflect.Kind) []TypeMeta {
+	types := []TypeMeta{}
+	for _, t := range s.Types() {
+		for _, kind := range kinds {
+			if t.Kind() == kind {
+				types = append(types, t)
+				break
+			}
+		}
+	}
+	return types
+}
+
+// Lookup returns the named type meta of the schema with the specified name. The name is matched against the qualified name of each type meta,
+// then against the unique names returned by `Names`, and lastly against the name of each type meta. If no type meta or multiple type metas match
+// the name, false is returned.
+func (s *Schema) Lookup(name string) (TypeMeta, bool) {
+	index := s.lookupIndex()
+	if t, ok := index.qualified[name]; ok {
+		return t, true
+	}
+	matches := index.unique[name]
+	if len(matches) == 0 {
+		matches = index.named[name]
+	}
+	if len(matches) != 1 {
+		return nil, false
+	}
+	return matches[0], true
+}
+
+// typeIndex indexes the named type metas of a schema by the names matched by `Lookup`
+type typeIndex struct {
+	generation uint64 // Fields generation when the index was built (see `fieldsChanged`)
+	types      int    // Number of type metas of the schema when the index was built, since type metas are only added
+	qualified  map[string]TypeMeta
+	unique     map[string][]TypeMeta
+	named      map[string][]TypeMeta
+}
+
+// lookupIndex returns the cached type index of the schema, building it if type metas have been added or renamed since it was built
+func (s *Schema) lookupIndex() *typeIndex {
+	generation := s.fieldsGeneration()
+	s.mu.Lock()
+	index, types := s.index, len(s.types)
+	s.mu.Unlock()
+	if index != nil && index.generation == generation && index.types == types {
+		return index
+	}
+	index = &typeIndex{
+		generation: generation,
+		types:      types,
+		qualified:  map[string]TypeMeta{},
+		unique:     map[string][]TypeMeta{},
+		named:      map[string][]TypeMeta{},
+	}
+	names, _ := s.Names()
+	for _, t := range s.namedTypes() {
+		if _, ok := index.qualified[QualifiedName(t)]; !ok {
+			index.qualified[QualifiedName(t)] = t
+		}
+		index.unique[names[t]] = append(index.unique[names[t]], t)
+		index.named[t.Name()] = append(index.named[t.Name()], t)
+	}
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+	return index
+}
+
 // NewSchema returns a new type meta schema
 func NewSchema() *Schema {
-	return &Schema{sync.Mutex{}, make(map[reflect.Type]TypeMeta), make(map[*Enum]TypeMeta)}
+	return &Schema{types: make(map[reflect.Type]TypeMeta), enumTypes: make(map[*Enum]TypeMeta)}
+}
+
+// schemaOf returns the specified schema, or the default schema if it is nil
+func schemaOf(s *Schema) *Schema {
+	if s == nil {
+		return DefaultSchema
+	}
+	return s
 }
 
 // DefaultSchema is the default type meta schema
@@ -242,6 +532,12 @@ func GetStruct(typ interface{}) *Struct {
 	return DefaultSchema.GetStruct(typ)
 }
 
+// GetFunc is `Get` but asserts the returned type meta to `*Func`, meaning it panics if the specified type is not a function.
+// If a function value is passed, a copy of the type meta is returned with its `FuncName` set to the name of the function.
+func GetFunc(typ interface{}) *Func {
+	return DefaultSchema.GetFunc(typ)
+}
+
 // SliceOf returns slice type meta for the specified type
 func SliceOf(typ interface{}) *Slice {
 	return DefaultSchema.SliceOf(typ)
@@ -276,6 +572,31 @@ var kindTypes = map[reflect.Kind]reflect.Type{
 	reflect.Interface:  reflect.TypeOf(nil),
 }
 
+// jsonUnsupported returns whether values of the type cannot be represented in JSON, i.e. (pointers to) channels, functions, and unsafe pointers
+func jsonUnsupported(t TypeMeta) bool {
+	switch NonPtr(t).(type) {
+	case *Chan, *Func, *UnsafePointer:
+		return true
+	default:
+		return false
+	}
+}
+
+// jsonQuotable returns whether the `,string` JSON option applies to values of the type, i.e. (pointers to) strings, numbers, and booleans
+func jsonQuotable(t TypeMeta) bool {
+	if ptr, ok := t.(*Ptr); ok {
+		t = ptr.Elem
+	}
+	switch t.Kind() {
+	case reflect.Bool, reflect.String, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
 var primitiveType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
 
 const underscoreChar rune = '_'
@@ -8,7 +8,8 @@ import (
 type Slice struct {
 	Elem TypeMeta
 
-	typ reflect.Type
+	typ         reflect.Type
+	annotations *annotationSet
 }
 
 // Primitive returns true for slices with primitive elements
@@ -38,10 +39,15 @@ func (s *Slice) Name() string {
 
 // Copy returns a copy of the ptr type meta
 func (s *Slice) Copy() *Slice {
-	ns := *s
+	ns := copyAnnotated(s)
+	ns.annotations = ns.annotations.clone()
 	return &ns
 }
 
 func (s *Slice) String() string {
 	return s.Type().String()
 }
+
+func (s *Slice) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -4,16 +4,22 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // Struct is type meta for a struct
 type Struct struct {
 	name         string
 	Description  string
+	Deprecated   string
+	Examples     []interface{}
 	StringParser func(string) (interface{}, error)
 	Fields       map[int]StructField
 
-	typ reflect.Type
+	typ         reflect.Type
+	annotations *annotationSet
+	schema      *Schema
+	visible     *visibleFieldsCache
 }
 
 // Field returns the field at the specified index. If no matching field is found, nil is returned.
@@ -25,23 +31,66 @@ func (s *Struct) Field(fieldIndex int) *StructField {
 	return &field
 }
 
+// directFieldByName returns the field declared directly in the struct with the specified struct field name or JSON field name.
+// If no matching field is found, directFieldByName panics.
+func (s *Struct) directFieldByName(fieldName string) StructField {
+	f := s.FindField(func(field StructField) bool {
+		return field.Name == fieldName || field.JSONName == fieldName
+	})
+	if f == nil {
+		panic("Field with name \"" + fieldName + "\" is not declared in " + s.String())
+	}
+	return *f
+}
+
 // FieldByName returns the field with the specified name. The specified name can either be the struct field name or the JSON field name.
-// If no matching field is found, nil is returned.
+// Fields declared directly in the struct are matched first, after which fields promoted from embedded structs (see `Struct.VisibleFields`) are matched,
+// and finally the aliases of fields (see `StructField.Aliases`). If no matching field is found, nil is returned.
 func (s *Struct) FieldByName(fieldName string) *StructField {
 	if fieldName == "" {
 		return nil
 	}
+	tag := s.nameTag()
 	field := s.FindField(func(field StructField) bool {
-		return field.Name == fieldName || field.JSONName == fieldName
+		return field.Name == fieldName || field.NameFor(tag) == fieldName
 	})
-	return field
+	if field != nil {
+		return field
+	}
+	for _, field := range s.visibleFieldsFor(tag) {
+		if field.Depth() > 0 && (field.NameFor(tag) == fieldName || field.Name == fieldName) {
+			return &field
+		}
+	}
+	for _, field := range s.visibleFieldsFor(tag) {
+		if field.hasAlias(fieldName, false) {
+			return &field
+		}
+	}
+	return nil
+}
+
+// FieldByNameFold returns the field with the specified name like `FieldByName`, but if no field matches exactly, the name is matched
+// case-insensitively, like `encoding/json` matches object keys when unmarshaling. If no matching field is found, nil is returned.
+func (s *Struct) FieldByNameFold(fieldName string) *StructField {
+	if field := s.FieldByName(fieldName); field != nil || fieldName == "" {
+		return field
+	}
+	tag := s.nameTag()
+	for _, field := range s.visibleFieldsFor(tag) {
+		if strings.EqualFold(field.NameFor(tag), fieldName) || strings.EqualFold(field.Name, fieldName) || field.hasAlias(fieldName, true) {
+			return &field
+		}
+	}
+	return nil
 }
 
 // FieldIndexByName returns the field index of a field with the specified name. The specified name can either
-// be the struct field name or the JSON field name. If no matching field is found, -1 is returned.
+// be the struct field name or the JSON field name. If no matching field is found, or the matching field is promoted
+// from an embedded struct and thus has no index in the struct (see `StructField.IndexPath`), -1 is returned.
 func (s *Struct) FieldIndexByName(fieldName string) int {
 	field := s.FieldByName(fieldName)
-	if field == nil {
+	if field == nil || len(field.indexPath()) > 1 {
 		return -1
 	}
 	return field.Index
@@ -84,9 +133,15 @@ func (s *Struct) IterateFields(iteratee func(StructField)) {
 	}
 }
 
+// Methods returns the methods of the struct type, including methods with a pointer receiver
+func (s *Struct) Methods() Methods {
+	return methodsOf(s.schema, s.typ)
+}
+
 // SetName sets the name of the struct type meta
 func (s *Struct) SetName(name string) *Struct {
 	s.name = name
+	schemaOf(s.schema).fieldsChanged()
 	return s
 }
 
@@ -96,6 +151,27 @@ func (s *Struct) SetDescription(description string) *Struct {
 	return s
 }
 
+// SetDeprecated sets the deprecation notice of the struct type meta, e.g. "use UserV2 instead"
+func (s *Struct) SetDeprecated(notice string) *Struct {
+	s.Deprecated = notice
+	return s
+}
+
+// SetExample sets the example values of the struct type meta (for API schemas etc.). The values are converted to the struct type,
+// e.g. from maps, including values of read-only fields. SetExample panics if a value cannot be converted.
+func (s *Struct) SetExample(examples ...interface{}) *Struct {
+	convertedExamples := make([]interface{}, len(examples))
+	for i, example := range examples {
+		convertedExample, err := (&Converter{Schema: s.schema, ReadOnly: AllowReadOnly}).ConvertInterfaceValue(example, s)
+		if err != nil {
+			panic("Invalid example of " + s.String() + ": " + err.Error())
+		}
+		convertedExamples[i] = convertedExample
+	}
+	s.Examples = convertedExamples
+	return s
+}
+
 // SetStringParser sets the string parser of the struct type meta
 func (s *Struct) SetStringParser(stringParser func(string) (interface{}, error)) *Struct {
 	s.StringParser = stringParser
@@ -104,17 +180,19 @@ func (s *Struct) SetStringParser(stringParser func(string) (interface{}, error))
 
 // SetField sets the type meta of the specifield field
 func (s *Struct) SetField(fieldName string, fieldType TypeMeta) *Struct {
-	field := s.EnsureFieldByName(fieldName)
+	field := s.directFieldByName(fieldName)
 	field.TypeMeta = fieldType
 	s.Fields[field.Index] = field
+	schemaOf(s.schema).fieldsChanged()
 	return s
 }
 
 // SetFieldElem sets the elem type meta of the specifield field
 func (s *Struct) SetFieldElem(fieldName string, fieldElemType TypeMeta) *Struct {
-	field := s.EnsureFieldByName(fieldName)
+	field := s.directFieldByName(fieldName)
 	field.TypeMeta = setElem(field.TypeMeta, fieldElemType)
 	s.Fields[field.Index] = field
+	schemaOf(s.schema).fieldsChanged()
 	return s
 }
 
@@ -134,10 +212,13 @@ func setElem(typeMeta TypeMeta, elemTypeMeta TypeMeta) TypeMeta {
 
 // Copy returns a copy of the struct type meta
 func (s *Struct) Copy() *Struct {
-	cs := *s
+	cs := copyAnnotated(s)
+	cs.visible = &visibleFieldsCache{}
+	cs.annotations = cs.annotations.clone()
 	if s.Fields != nil {
 		cs.Fields = map[int]StructField{}
 		for index, field := range s.Fields {
+			field.annotations = field.annotations.clone()
 			cs.Fields[index] = field
 		}
 	}
@@ -176,3 +257,7 @@ func (s *Struct) Name() string {
 func (s *Struct) String() string {
 	return s.typ.String()
 }
+
+func (s *Struct) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
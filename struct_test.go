@@ -1,6 +1,7 @@
 package typemeta
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -139,3 +140,123 @@ func TestStruct(t *testing.T) {
 		t.Error("_ should be private")
 	}
 }
+
+func TestStructVisibleFields(t *testing.T) {
+	type Timestamps struct {
+		CreatedAt time.Time `json:"createdAt"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+	type Audit struct {
+		CreatedBy string `json:"createdBy"`
+		UpdatedAt string `json:"updatedAt"`
+	}
+	type StructA struct {
+		Timestamps
+		*Audit
+		Name      string `json:"name"`
+		CreatedBy string `json:"createdBy"`
+	}
+	structA := GetStruct(StructA{})
+
+	names := []string{}
+	for _, field := range structA.VisibleFields() {
+		names = append(names, field.JSONName)
+	}
+	if fmt.Sprint(names) != "[createdAt name createdBy]" {
+		t.Error("unexpected visible fields " + fmt.Sprint(names))
+	}
+
+	createdAt := structA.FieldByName("createdAt")
+	if createdAt == nil {
+		t.Fatal("promoted field createdAt should be found")
+	}
+	if fmt.Sprint(createdAt.IndexPath) != "[0 0]" {
+		t.Error("createdAt should have index path [0 0] but has " + fmt.Sprint(createdAt.IndexPath))
+	}
+	if index := structA.FieldIndexByName("createdAt"); index != -1 {
+		t.Error("promoted field createdAt should have no field index but has " + fmt.Sprint(index))
+	}
+	if index := structA.FieldIndexByName("name"); index != 2 {
+		t.Error("name should have field index 2 but has " + fmt.Sprint(index))
+	}
+	if structA.FieldByName("updatedAt") != nil {
+		t.Error("ambiguous field updatedAt should not be found")
+	}
+	if createdBy := structA.FieldByName("createdBy"); createdBy == nil || createdBy.Depth() != 0 {
+		t.Error("createdBy should be shadowed by the field declared in the struct")
+	}
+
+	t.Run("converts promoted fields", func(t *testing.T) {
+		type StructB struct {
+			*Audit
+			Name string `json:"name"`
+		}
+		v, err := ConvertInterfaceValue(map[string]interface{}{"name": "Test", "updatedAt": "now"}, StructB{})
+		if err != nil {
+			t.Fatal("failed converting promoted fields: " + err.Error())
+		}
+		if b := v.(StructB); b.Audit == nil || b.UpdatedAt != "now" {
+			t.Error("promoted field was not set")
+		}
+	})
+
+	t.Run("caches per schema", func(t *testing.T) {
+		s1, s2 := NewSchema(), NewSchema()
+		s2.GetStruct(StructA{}).VisibleFields()
+		generation := s2.fieldsGeneration()
+		s1.GetStruct(StructA{}).SetName("Renamed")
+		if s2.fieldsGeneration() != generation {
+			t.Error("expected changes to another schema not to invalidate cached fields")
+		}
+		s2.GetStruct(StructA{}).EditField("Name").JSONName("title")
+		if s2.GetStruct(StructA{}).FieldByName("title") == nil || s1.GetStruct(StructA{}).FieldByName("title") != nil {
+			t.Error("expected edited field to be visible only in the schema of the struct")
+		}
+	})
+}
+
+func TestStructEditField(t *testing.T) {
+	type Timestamps struct {
+		CreatedAt time.Time
+	}
+	type StructA struct {
+		Timestamps
+		Name  string
+		Count int
+	}
+	structA := NewSchema().GetStruct(StructA{})
+	structA.EditField("Name").Describe("The name").Default("Unnamed").JSONName("name").Deprecate("use title instead").Example("Test")
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("editing a promoted field should panic")
+			}
+		}()
+		structA.EditField("CreatedAt")
+	}()
+	structA.schema.GetStruct(Timestamps{}).EditField("CreatedAt").JSONName("createdAt").Describe("Creation time")
+	structA.EditField("Count").Exclude().Example("5").Default("1")
+
+	name := structA.FieldByName("name")
+	if name == nil {
+		t.Fatal("field should be found by its edited JSON name")
+	}
+	if name.Description != "The name" || name.DefaultValue != "Unnamed" || name.Deprecated != "use title instead" || fmt.Sprint(name.Examples) != "[Test]" {
+		t.Error("field does not have the edited metadata")
+	}
+	if createdAt := structA.FieldByName("createdAt"); createdAt == nil || createdAt.Description != "Creation time" || createdAt.Depth() != 1 {
+		t.Error("promoted field should be found by its edited JSON name")
+	}
+	count := structA.EnsureFieldByName("Count")
+	if !count.JSONExcluded || count.JSONName != "" {
+		t.Error("field should be excluded from JSON")
+	}
+	if len(count.Examples) != 1 || count.Examples[0] != 5 || count.DefaultValue != 1 {
+		t.Error("example and default value should be converted to the type of the field")
+	}
+	for _, field := range structA.VisibleFields() {
+		if field.Name == "Count" {
+			t.Error("excluded field should not be visible")
+		}
+	}
+}
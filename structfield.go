@@ -6,17 +6,28 @@ import (
 
 // StructField is type meta for a struct
 type StructField struct {
-	Name          string          // Struct field name
-	Index         int             // Index of the field in its parent struct
-	Anonymous     bool            // Whether the field is anonymous/embedded
-	Private       bool            // Whether the field is private to the package it is defined in, i.e. starting with a lowercase letter
-	JSONName      string          // JSON name of the field
-	JSONExcluded  bool            // Whether the field is excluded when the struct is marshaled to JSON
-	JSONOmitEmpty bool            // Whether the value of the field should be set to null when zero
-	Description   string          // Description (for API schemas etc.)
-	DefaultValue  interface{}     // Default value (for API schemas etc.)
-	Tags          *structtag.Tags // Parsed struct field tags
-	TypeMeta                      // Type meta of the field value
+	Name          string                 // Struct field name
+	Index         int                    // Index of the field in its parent struct
+	IndexPath     []int                  // Index sequence of the field from the struct it was retrieved from, as used by `reflect.Value.FieldByIndex`
+	Anonymous     bool                   // Whether the field is anonymous/embedded
+	Private       bool                   // Whether the field is private to the package it is defined in, i.e. starting with a lowercase letter
+	JSONName      string                 // JSON name of the field
+	JSONExcluded  bool                   // Whether the field is excluded when the struct is marshaled to JSON
+	JSONOmitEmpty bool                   // Whether the value of the field should be set to null when zero
+	JSONString    bool                   // Whether the value of the field is quoted as a JSON string, as with the `,string` option of `encoding/json`
+	Description   string                 // Description (for API schemas etc.)
+	DefaultValue  interface{}            // Default value of the type of the field, e.g. parsed from the `default` tag like examples (for API schemas etc.)
+	Deprecated    string                 // Deprecation notice, empty unless the field is deprecated (for API schemas etc.)
+	Examples      []interface{}          // Example values (for API schemas etc.)
+	Access        Access                 // Whether the value of the field is accepted when decoding and emitted when encoding
+	Sensitive     bool                   // Whether the value of the field is sensitive, e.g. a token, and should be masked by `Redact`
+	Aliases       []string               // Alternative names of the field, e.g. old JSON names, matched by `Struct.FieldByName`
+	Tags          *structtag.Tags        // Parsed struct field tags
+	Formats       map[string]FieldFormat // Metadata of the field for the yaml, xml, bson, msgpack, form, and db formats by tag (see `Format`)
+	TypeMeta                             // Type meta of the field value
+
+	jsonNamed   bool
+	annotations *annotationSet
 }
 
 // JSONNonNull returns whether the value will never be defined as null in JSON
@@ -24,6 +35,14 @@ func (sf StructField) JSONNonNull() bool {
 	return !(sf.JSONOmitEmpty || sf.TypeMeta.JSONNonNull())
 }
 
+// Depth returns how many embedded structs the field is promoted through, i.e. 0 for a field declared directly in the struct
+func (sf StructField) Depth() int {
+	if len(sf.IndexPath) == 0 {
+		return 0
+	}
+	return len(sf.IndexPath) - 1
+}
+
 func (sf StructField) String() string {
 	return sf.Name + ": " + sf.TypeMeta.String()
 }
@@ -38,3 +57,28 @@ func (sf StructField) Tag(key string) *structtag.Tag {
 	}
 	return nil
 }
+
+// indexPath returns the index path of the field, falling back to its index for fields without one
+func (sf StructField) indexPath() []int {
+	if len(sf.IndexPath) == 0 {
+		return []int{sf.Index}
+	}
+	return sf.IndexPath
+}
+
+// jsonTagged returns whether the JSON name of the field was explicitly set with a tag or a field editor
+func (sf StructField) jsonTagged() bool {
+	if sf.jsonNamed {
+		return true
+	}
+	tag := sf.Tag("json")
+	return tag != nil && tag.Name != "" && tag.Name != "-"
+}
+
+// annotationSet returns the annotations of the field, which are created along with the field by a schema and shared by all copies of it
+func (sf StructField) annotationSet(create bool) *annotationSet {
+	if sf.annotations == nil && create {
+		panic("Field \"" + sf.Name + "\" has not been created by a schema and cannot be annotated")
+	}
+	return sf.annotations
+}
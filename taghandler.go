@@ -0,0 +1,8 @@
+package typemeta
+
+import (
+	"github.com/fatih/structtag"
+)
+
+// TagHandler handles a struct tag of a field when a struct is built by a schema. See `Schema.RegisterTagHandler`.
+type TagHandler func(field *StructField, tag *structtag.Tag) error
@@ -0,0 +1,58 @@
+package typemeta
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/fatih/structtag"
+)
+
+func TestTagHandler(t *testing.T) {
+	type StructA struct {
+		Timeout int    `json:"timeout" unit:"ms"`
+		Email   string `pii:"email"`
+		Delay   int    `unit:""`
+	}
+	// struct with a malformed tag, which would not pass vet if declared
+	structB := reflect.StructOf([]reflect.StructField{{Name: "Broken", Type: reflect.TypeOf(""), Tag: `json:"broken`}})
+	unit := NewKey[string]("unit")
+	s := NewSchema().RegisterTagHandler("unit", func(field *StructField, tag *structtag.Tag) error {
+		if tag.Name == "" {
+			return errors.New("unit is required")
+		}
+		Annotate(field, unit, tag.Name)
+		return nil
+	}).RegisterTagHandler("pii", func(field *StructField, tag *structtag.Tag) error {
+		field.Description = "Personal data (" + tag.Name + ")"
+		return nil
+	})
+
+	err := s.Register(StructA{}, structB)
+	var errs Errors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatal("expected 2 errors but received " + err.Error())
+	}
+	var fieldErr *FieldError
+	if !errors.As(errs[0], &fieldErr) || fieldErr.Field != "Delay" {
+		t.Error("expected an error of the Delay field but received " + errs[0].Error())
+	}
+	if !errors.As(errs[1], &fieldErr) || fieldErr.Field != "Broken" || !strings.Contains(fieldErr.Error(), "failed parsing tags") {
+		t.Error("expected a tag parsing error of the Broken field but received " + errs[1].Error())
+	}
+	if s.Err().Error() != err.Error() {
+		t.Error("schema should report the same errors")
+	}
+	if s.Register(StructA{}) != nil {
+		t.Error("registering a built type should not report errors again")
+	}
+
+	structA := s.GetStruct(StructA{})
+	if v, _ := Annotation(structA.EnsureFieldByName("timeout"), unit); v != "ms" {
+		t.Error("timeout field should have been annotated with its unit")
+	}
+	if structA.EnsureFieldByName("Email").Description != "Personal data (email)" {
+		t.Error("email field should have been described by the handler")
+	}
+}
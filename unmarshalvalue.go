@@ -2,64 +2,118 @@ package typemeta
 
 import (
 	"encoding/json"
+	"errors"
 	"reflect"
 )
 
 // UnmarshalValue unmarshals data, sets default values, and returns an error if unsuccessful.
 // It is much, much slower than `json.Unmarshal`. Not sure why you would even use this tbh.
 func UnmarshalValue(t interface{}, data []byte) (interface{}, error) {
-	rv, err := unmarshalValue(t, data)
+	return DefaultSchema.UnmarshalValue(t, data)
+}
+
+// UnmarshalValue unmarshals data, sets default values, and returns an error if unsuccessful.
+func (s *Schema) UnmarshalValue(t interface{}, data []byte) (interface{}, error) {
+	return (&Converter{Schema: s}).UnmarshalValue(t, data)
+}
+
+// UnmarshalValue unmarshals data, sets default values, and returns an error if unsuccessful.
+func (c *Converter) UnmarshalValue(t interface{}, data []byte) (interface{}, error) {
+	rv, err := unmarshalValue(c, schemaOf(c.Schema).Get(t), data)
 	if err != nil {
 		return rv.Interface(), err
 	}
 	return rv.Interface(), nil
 }
 
-func unmarshalValue(t interface{}, data []byte) (reflect.Value, error) {
-	tm := Get(t)
+func unmarshalValue(c *Converter, tm TypeMeta, data []byte) (reflect.Value, error) {
+	return c.conversion().unmarshal(tm, data)
+}
+
+// unmarshal unmarshals JSON into a value of the specified type meta
+func (c *conversion) unmarshal(tm TypeMeta, data []byte) (reflect.Value, error) {
+	c.unmarshaling = true
+	if p, ok := NonPtr(tm).(*Primitive); ok {
+		if p.scalar != nil && p.scalar.Parse != nil {
+			var decoded interface{}
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				return reflect.New(tm.Type()).Elem(), err
+			}
+			if decoded == nil {
+				return reflect.New(tm.Type()).Elem(), nil
+			}
+			return c.convert(reflect.ValueOf(decoded), tm)
+		}
+		if scannedPrimitive(p) {
+			var decoded interface{}
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				return reflect.New(tm.Type()).Elem(), err
+			}
+			switch decoded.(type) {
+			case nil:
+				return reflect.New(tm.Type()).Elem(), nil
+			case map[string]interface{}, []interface{}:
+				// not a driver value, e.g. a scanner marshaled like by `encoding/json`
+				return c.unmarshalJSON(tm, data)
+			}
+			return c.convert(reflect.ValueOf(decoded), tm)
+		}
+		// possibly a struct, array, or slice unmarshaled by its own codec methods
+		return c.unmarshalJSON(tm, data)
+	}
 	nonPtrKind := NonPtr(tm).Kind()
 	switch nonPtrKind {
+	case reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return reflect.New(tm.Type()).Elem(), errors.New("cannot unmarshal into unsupported type " + tm.String())
 	case reflect.Array, reflect.Slice:
 		rv := reflect.New(reflect.TypeOf([]interface{}{}))
-		err := json.Unmarshal(data, rv.Interface())
+		err := c.decode(data, rv.Interface())
 		if err != nil {
 			return rv.Elem(), err
 		}
-		rv, err = ConvertValue(rv.Elem(), tm)
+		rv, err = c.convert(rv.Elem(), tm)
 		if err != nil {
 			return rv, err
 		}
 		return rv, nil
 	case reflect.Map:
 		rv := reflect.New(reflect.TypeOf(map[string]interface{}{}))
-		err := json.Unmarshal(data, rv.Interface())
+		err := c.decode(data, rv.Interface())
 		if err != nil {
 			return rv.Elem(), err
 		}
-		rv, err = ConvertValue(rv.Elem(), tm)
+		rv, err = c.convert(rv.Elem(), tm)
 		if err != nil {
 			return rv, err
 		}
 		return rv, nil
 	case reflect.Struct:
-		if tm.Primitive() {
-			break
-		}
 		rv := reflect.New(reflect.TypeOf(map[string]interface{}{}))
-		err := json.Unmarshal(data, rv.Interface())
+		err := c.decode(data, rv.Interface())
 		if err != nil {
 			return rv.Elem(), err
 		}
-		rv, err = ConvertValue(rv.Elem(), tm)
+		rv, err = c.convert(rv.Elem(), tm)
 		if err != nil {
 			return rv, err
 		}
 		return rv, nil
 	}
+	return c.unmarshalJSON(tm, data)
+}
+
+// unmarshalJSON unmarshals JSON into a value of the specified type meta using `json.Unmarshal`
+func (c *conversion) unmarshalJSON(tm TypeMeta, data []byte) (reflect.Value, error) {
 	rv := reflect.New(tm.Type())
 	err := json.Unmarshal(data, rv.Interface())
 	if err != nil {
 		return rv.Elem(), err
 	}
+	c.reportDeprecatedValue(rv.Elem(), tm)
 	return rv.Elem(), nil
 }
+
+// scannedPrimitive returns whether values of a primitive type are scanned (see `sql.Scanner`) rather than unmarshaled from JSON
+func scannedPrimitive(p *Primitive) bool {
+	return p.Implements(ScannerCodec) && !p.Implements(JSONUnmarshalerCodec) && !p.Implements(TextUnmarshalerCodec)
+}
@@ -0,0 +1,44 @@
+package typemeta
+
+import (
+	"reflect"
+)
+
+// UnsafePointer is type meta for an opaque `unsafe.Pointer`
+type UnsafePointer struct {
+	typ         reflect.Type
+	annotations *annotationSet
+}
+
+// Type returns the type of the unsafe pointer
+func (s *UnsafePointer) Type() reflect.Type {
+	return s.typ
+}
+
+// Kind returns `reflect.UnsafePointer` for unsafe pointers
+func (s *UnsafePointer) Kind() reflect.Kind {
+	return reflect.UnsafePointer
+}
+
+// Primitive returns false for unsafe pointers
+func (s *UnsafePointer) Primitive() bool {
+	return false
+}
+
+// JSONNonNull returns false for unsafe pointers
+func (s *UnsafePointer) JSONNonNull() bool {
+	return false
+}
+
+// Name returns the type meta's explicitly set name or the type's name within its package for a defined type. For other (non-defined) types it returns the empty string.
+func (s *UnsafePointer) Name() string {
+	return s.typ.Name()
+}
+
+func (s *UnsafePointer) String() string {
+	return s.Type().String()
+}
+
+func (s *UnsafePointer) annotationSet(create bool) *annotationSet {
+	return lazyAnnotationSet(&s.annotations, create)
+}
@@ -24,3 +24,51 @@ func TestUtil(t *testing.T) {
 		}
 	})
 }
+
+func TestFieldPath(t *testing.T) {
+	type Item struct {
+		SKU string `json:"sku"`
+	}
+	type Order struct {
+		Items []*Item `json:"items"`
+	}
+	type Address struct {
+		Street string `json:"street"`
+	}
+	type Customer struct {
+		Address *Address           `json:"address"`
+		Orders  map[string][]Order `json:"orders"`
+	}
+	customer := GetStruct(Customer{})
+	cd := []struct {
+		path  string
+		field string
+		index string
+	}{
+		{"address.street", "Address.Street", "[0 0]"},
+		{"Address.Street", "Address.Street", "[0 0]"},
+		{"orders[][].items[].sku", "Orders.Items.SKU", "[1 0 0]"},
+		{"orders.items.SKU", "Orders.Items.SKU", "[1 0 0]"},
+	}
+	for _, cd := range cd {
+		p := customer.FieldByPath(cd.path)
+		if p == nil {
+			t.Error("no field found at path " + cd.path)
+			continue
+		}
+		if p.String() != cd.field || fmt.Sprint(p.Index) != cd.index {
+			t.Error("expected " + cd.field + " " + cd.index + " at path " + cd.path + " but received " + p.String() + " " + fmt.Sprint(p.Index))
+		}
+		if sf := StructFieldAt(customer, p.Index); sf == nil || sf.Name != p.Field().Name {
+			t.Error("index path of " + cd.path + " does not point to the same field")
+		}
+	}
+	for _, path := range []string{"address[].street", "orders[][][]", "street", "address..street", "orders[.items"} {
+		if p := customer.FieldByPath(path); p != nil {
+			t.Error("expected no field at path " + path + " but received " + p.String())
+		}
+	}
+	if p := FieldPath(Get([]Customer{}), "[].address.street"); p == nil || p.String() != "Address.Street" {
+		t.Error("expected field at path [].address.street")
+	}
+}
@@ -0,0 +1,165 @@
+package typemeta
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// VisibleFields returns the fields of the struct as seen by `encoding/json`, i.e. including fields promoted from embedded structs.
+// Promoted fields are shadowed by shallower fields with the same JSON name, tagged fields take precedence over untagged fields at the same
+// depth, and ambiguous fields (same JSON name, depth, and tagging) are left out. Fields excluded from JSON are left out, too.
+// If the schema of the struct has another name tag (see `Schema.SetNameTag`), the names of that format are used instead of JSON names.
+// The `IndexPath` of each returned field is the full index sequence from the struct. The fields are ordered by index sequence.
+func (s *Struct) VisibleFields() []StructField {
+	return s.visibleFieldsFor(s.nameTag())
+}
+
+// visibleFieldsFor returns the visible fields of the struct using the names of the format of the specified tag
+func (s *Struct) visibleFieldsFor(tag string) []StructField {
+	if s.visible == nil {
+		return s.visibleFields(tag)
+	}
+	generation := schemaOf(s.schema).fieldsGeneration()
+	s.visible.mu.Lock()
+	defer s.visible.mu.Unlock()
+	if s.visible.fields == nil || s.visible.generation != generation {
+		s.visible.fields = map[string][]StructField{}
+		s.visible.generation = generation
+	}
+	visibleFields, ok := s.visible.fields[tag]
+	if !ok {
+		visibleFields = s.visibleFields(tag)
+		s.visible.fields[tag] = visibleFields
+	}
+	fields := make([]StructField, len(visibleFields))
+	copy(fields, visibleFields)
+	return fields
+}
+
+func (s *Struct) visibleFields(tag string) []StructField {
+	type embedded struct {
+		strct *Struct
+		index []int
+	}
+	current := []embedded{}
+	next := []embedded{{strct: s}}
+	count := map[*Struct]int{}
+	nextCount := map[*Struct]int{}
+	visited := map[*Struct]bool{}
+	type visibleField struct {
+		StructField
+		format FieldFormat
+	}
+	fields := []visibleField{}
+	for len(next) > 0 {
+		current, next = next, current[:0]
+		count, nextCount = nextCount, map[*Struct]int{}
+		for _, e := range current {
+			if visited[e.strct] {
+				continue
+			}
+			visited[e.strct] = true
+			for fieldIndex := 0; fieldIndex < e.strct.typ.NumField(); fieldIndex++ {
+				field := e.strct.Fields[fieldIndex]
+				format := field.Format(tag)
+				index := make([]int, len(e.index)+1)
+				copy(index, e.index)
+				index[len(e.index)] = fieldIndex
+				if field.Anonymous {
+					embeddedStruct, _ := NonPtr(field.TypeMeta).(*Struct)
+					if field.Private && (embeddedStruct == nil || field.Kind() == reflect.Ptr) {
+						// unexported non-struct types and pointers to unexported struct types cannot be set
+						continue
+					}
+					if embeddedStruct != nil && !format.Tagged {
+						nextCount[embeddedStruct]++
+						if nextCount[embeddedStruct] == 1 {
+							next = append(next, embedded{embeddedStruct, index})
+						}
+						continue
+					}
+				} else if field.Private {
+					continue
+				}
+				if format.Excluded {
+					continue
+				}
+				field.IndexPath = index
+				fields = append(fields, visibleField{field, format})
+				if count[e.strct] > 1 {
+					// the struct is embedded multiple times at the same depth, so add a duplicate to annihilate the field
+					fields = append(fields, visibleField{field, format})
+				}
+			}
+		}
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		if fields[i].format.Name != fields[j].format.Name {
+			return fields[i].format.Name < fields[j].format.Name
+		}
+		if len(fields[i].IndexPath) != len(fields[j].IndexPath) {
+			return len(fields[i].IndexPath) < len(fields[j].IndexPath)
+		}
+		if fields[i].format.Tagged != fields[j].format.Tagged {
+			return fields[i].format.Tagged
+		}
+		return indexPathLess(fields[i].IndexPath, fields[j].IndexPath)
+	})
+
+	// keep the dominant field of each name
+	dominantFields := []StructField{}
+	for advance, i := 0, 0; i < len(fields); i += advance {
+		name := fields[i].format.Name
+		for advance = 1; i+advance < len(fields); advance++ {
+			if fields[i+advance].format.Name != name {
+				break
+			}
+		}
+		if advance > 1 && len(fields[i].IndexPath) == len(fields[i+1].IndexPath) && fields[i].format.Tagged == fields[i+1].format.Tagged {
+			// ambiguous field
+			continue
+		}
+		dominantFields = append(dominantFields, fields[i].StructField)
+	}
+
+	sort.Slice(dominantFields, func(i, j int) bool {
+		return indexPathLess(dominantFields[i].IndexPath, dominantFields[j].IndexPath)
+	})
+	return dominantFields
+}
+
+// nameTag returns the tag of the format used to match field names of the struct (see `Schema.SetNameTag`)
+func (s *Struct) nameTag() string {
+	return schemaOf(s.schema).NameTag()
+}
+
+func indexPathLess(a, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// visibleFieldsCache caches the visible fields of a struct by name tag until struct fields are changed
+type visibleFieldsCache struct {
+	mu         sync.Mutex
+	fields     map[string][]StructField
+	generation uint64
+}
+
+// fieldsChanged increments the fields generation of the schema whenever the fields of a struct type meta or the names of type metas of the
+// schema are changed, invalidating its cached visible fields and type index (see `Lookup`). Since fields of a struct may be promoted to any
+// struct embedding it, all caches of the schema are invalidated.
+func (s *Schema) fieldsChanged() {
+	atomic.AddUint64(&s.generation, 1)
+}
+
+// fieldsGeneration returns the fields generation of the schema (see `fieldsChanged`)
+func (s *Schema) fieldsGeneration() uint64 {
+	return atomic.LoadUint64(&s.generation)
+}
@@ -0,0 +1,190 @@
+package typemeta
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// StepKind is the kind of a step in a path between type metas
+type StepKind int
+
+const (
+	// FieldStep is a step to the type meta of a struct field
+	FieldStep StepKind = iota
+	// PtrStep is a step to the element of a pointer
+	PtrStep
+	// ElemStep is a step to the element of a slice, array, map, or channel
+	ElemStep
+	// KeyStep is a step to the key of a map
+	KeyStep
+	// InStep is a step to a parameter of a function
+	InStep
+	// OutStep is a step to a result of a function
+	OutStep
+)
+
+// PathStep is a step in a path between type metas
+type PathStep struct {
+	Kind  StepKind
+	Field *StructField // Struct field of a `FieldStep`
+	Index int          // Parameter or result index of an `InStep` or `OutStep`
+}
+
+// Path is a path from a root type meta to a nested type meta
+type Path []PathStep
+
+// String returns the path in the format accepted by `FieldPath` as far as possible, e.g. "orders[].items[].sku".
+// Map keys are represented by "[key]", and function parameters and results by "in[0]" and "out[0]".
+func (p Path) String() string {
+	var b strings.Builder
+	for _, step := range p {
+		switch step.Kind {
+		case FieldStep:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			if step.Field.JSONName != "" {
+				b.WriteString(step.Field.JSONName)
+			} else {
+				b.WriteString(step.Field.Name)
+			}
+		case ElemStep:
+			b.WriteString("[]")
+		case KeyStep:
+			b.WriteString("[key]")
+		case InStep, OutStep:
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+			if step.Kind == InStep {
+				b.WriteString("in")
+			} else {
+				b.WriteString("out")
+			}
+			b.WriteString("[" + strconv.Itoa(step.Index) + "]")
+		}
+	}
+	return b.String()
+}
+
+// Visitor visits the type metas of a type graph. The path passed to the visitor is only valid during the call, so it has to be copied in order to be retained.
+type Visitor interface {
+	// Enter is called when a type meta is visited for the first time, before its nested type metas are visited.
+	// If `SkipType` is returned, the nested type metas are not visited and Leave is not called. Any other error stops the walk.
+	Enter(t TypeMeta, path Path) error
+	// Leave is called after the nested type metas of a type meta have been visited. An error stops the walk.
+	Leave(t TypeMeta, path Path) error
+	// BackEdge is called when a type meta is reached from one of its nested type metas, i.e. when the type graph has a cycle. An error stops the walk.
+	BackEdge(t TypeMeta, path Path) error
+}
+
+// SkipType is returned by `Visitor.Enter` to skip visiting the nested type metas of a type meta
+var SkipType = errors.New("skip type")
+
+// VisitorFuncs is a `Visitor` calling the functions that are non-nil
+type VisitorFuncs struct {
+	EnterFunc    func(t TypeMeta, path Path) error
+	LeaveFunc    func(t TypeMeta, path Path) error
+	BackEdgeFunc func(t TypeMeta, path Path) error
+}
+
+// Enter calls EnterFunc if it is non-nil
+func (v VisitorFuncs) Enter(t TypeMeta, path Path) error {
+	if v.EnterFunc == nil {
+		return nil
+	}
+	return v.EnterFunc(t, path)
+}
+
+// Leave calls LeaveFunc if it is non-nil
+func (v VisitorFuncs) Leave(t TypeMeta, path Path) error {
+	if v.LeaveFunc == nil {
+		return nil
+	}
+	return v.LeaveFunc(t, path)
+}
+
+// BackEdge calls BackEdgeFunc if it is non-nil
+func (v VisitorFuncs) BackEdge(t TypeMeta, path Path) error {
+	if v.BackEdgeFunc == nil {
+		return nil
+	}
+	return v.BackEdgeFunc(t, path)
+}
+
+// Walk walks the type graph of the specified type meta depth-first, visiting each type meta once. Struct fields are walked in index order,
+// map keys before map elements, and function parameters before function results. The error returned by the visitor is returned, except for `SkipType`.
+func Walk(t TypeMeta, v Visitor) error {
+	w := walker{v, map[TypeMeta]bool{}, map[TypeMeta]bool{}}
+	return w.walk(t, Path{})
+}
+
+type walker struct {
+	visitor Visitor
+	visited map[TypeMeta]bool
+	onPath  map[TypeMeta]bool
+}
+
+func (w walker) walk(t TypeMeta, path Path) error {
+	if t == nil {
+		return nil
+	}
+	path = path[:len(path):len(path)]
+	if w.onPath[t] {
+		return w.visitor.BackEdge(t, path)
+	}
+	if w.visited[t] {
+		return nil
+	}
+	w.visited[t] = true
+	if err := w.visitor.Enter(t, path); err != nil {
+		if err == SkipType {
+			return nil
+		}
+		return err
+	}
+	w.onPath[t] = true
+	if err := w.walkNested(t, path); err != nil {
+		return err
+	}
+	delete(w.onPath, t)
+	return w.visitor.Leave(t, path)
+}
+
+func (w walker) walkNested(t TypeMeta, path Path) error {
+	switch t := t.(type) {
+	case *Struct:
+		for fieldIndex := 0; fieldIndex < t.typ.NumField(); fieldIndex++ {
+			field := t.Fields[fieldIndex]
+			if err := w.walk(field.TypeMeta, append(path, PathStep{Kind: FieldStep, Field: &field})); err != nil {
+				return err
+			}
+		}
+	case *Ptr:
+		return w.walk(t.Elem, append(path, PathStep{Kind: PtrStep}))
+	case *Slice:
+		return w.walk(t.Elem, append(path, PathStep{Kind: ElemStep}))
+	case *Array:
+		return w.walk(t.Elem, append(path, PathStep{Kind: ElemStep}))
+	case *Chan:
+		return w.walk(t.Elem, append(path, PathStep{Kind: ElemStep}))
+	case *Map:
+		if err := w.walk(t.Key, append(path, PathStep{Kind: KeyStep})); err != nil {
+			return err
+		}
+		return w.walk(t.Elem, append(path, PathStep{Kind: ElemStep}))
+	case *Func:
+		for i, in := range t.In {
+			if err := w.walk(in, append(path, PathStep{Kind: InStep, Index: i})); err != nil {
+				return err
+			}
+		}
+		for i, out := range t.Out {
+			if err := w.walk(out, append(path, PathStep{Kind: OutStep, Index: i})); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
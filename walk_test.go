@@ -0,0 +1,64 @@
+package typemeta
+
+import (
+	"fmt"
+	"testing"
+)
+
+type walkNode struct {
+	Name     string      `json:"name"`
+	Children []*walkNode `json:"children"`
+	Meta     map[string]walkMeta
+}
+
+type walkMeta struct {
+	Labels []string `json:"labels"`
+}
+
+func TestWalk(t *testing.T) {
+	entered := []string{}
+	left := 0
+	backEdges := []string{}
+	err := Walk(Get(walkNode{}), VisitorFuncs{
+		EnterFunc: func(t TypeMeta, path Path) error {
+			entered = append(entered, path.String()+" "+t.String())
+			if _, ok := t.(*Struct); ok && len(path) > 0 {
+				return SkipType
+			}
+			return nil
+		},
+		LeaveFunc: func(t TypeMeta, path Path) error {
+			left++
+			return nil
+		},
+		BackEdgeFunc: func(t TypeMeta, path Path) error {
+			backEdges = append(backEdges, path.String()+" "+t.String())
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatal("failed walking: " + err.Error())
+	}
+	expected := []string{
+		" typemeta.walkNode",
+		"name string",
+		"children []*typemeta.walkNode",
+		"children[] *typemeta.walkNode",
+		"Meta map[string]typemeta.walkMeta",
+		"Meta[] typemeta.walkMeta",
+	}
+	if fmt.Sprint(entered) != fmt.Sprint(expected) {
+		t.Error("expected to enter " + fmt.Sprint(expected) + " but entered " + fmt.Sprint(entered))
+	}
+	if left != len(entered)-1 {
+		t.Error("expected to leave all entered type metas except the skipped one")
+	}
+	if fmt.Sprint(backEdges) != "[children[] typemeta.walkNode]" {
+		t.Error("expected a back edge to walkNode but received " + fmt.Sprint(backEdges))
+	}
+
+	stop := fmt.Errorf("stop")
+	if err := Walk(Get(walkNode{}), VisitorFuncs{EnterFunc: func(t TypeMeta, path Path) error { return stop }}); err != stop {
+		t.Error("expected the error of the visitor to be returned")
+	}
+}